@@ -0,0 +1,239 @@
+package authz
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+
+	corecontainer "github.com/rayyone/go-core/container"
+	"github.com/rayyone/go-core/helpers/i18n"
+	corerp "github.com/rayyone/go-core/repositories"
+	"github.com/rayyone/go-core/ryerr"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// Wildcard matches any action or resource in a permission, e.g. "*:posts" or "read:*"
+	Wildcard = "*"
+	// Own restricts a permission to the resources owned by the user, e.g. "update:posts:own". See Owned
+	Own = "own"
+	// PermissionsClaim is the token claim holding permissions granted directly, in addition to the ones of the roles
+	PermissionsClaim = "permissions"
+)
+
+// Reasons of the authorization failures, returned as the error code of the response
+var (
+	CodeUnauthenticated   = ryerr.RegisterCode("AUTHZ_UNAUTHENTICATED", 401, "Authentication is required.", false)
+	CodeMissingPermission = ryerr.RegisterCode("AUTHZ_MISSING_PERMISSION", 403, "You don't have the permission to do this.", false)
+	CodeNotOwner          = ryerr.RegisterCode("AUTHZ_NOT_OWNER", 403, "You can only do this on your own resources.", false)
+)
+
+func init() {
+	i18n.RegisterMessages(i18n.Vietnamese, map[string]string{
+		i18n.ErrorKey(CodeUnauthenticated.Code):   "Bạn cần đăng nhập.",
+		i18n.ErrorKey(CodeMissingPermission.Code): "Bạn không có quyền thực hiện thao tác này.",
+		i18n.ErrorKey(CodeNotOwner.Code):          "Bạn chỉ có thể thực hiện thao tác này trên tài nguyên của mình.",
+	})
+	i18n.RegisterMessages(i18n.Japanese, map[string]string{
+		i18n.ErrorKey(CodeUnauthenticated.Code):   "認証が必要です。",
+		i18n.ErrorKey(CodeMissingPermission.Code): "この操作を行う権限がありません。",
+		i18n.ErrorKey(CodeNotOwner.Code):          "この操作はご自身のリソースに対してのみ可能です。",
+	})
+}
+
+// Resource names itself in permissions. Other resources are named after their gorm table name, e.g. Post is "posts"
+type Resource interface {
+	ResourceName() string
+}
+
+// Owned resources can be acted on with "own" permissions by their owner
+type Owned interface {
+	OwnerID() string
+}
+
+var (
+	sourceRWLock sync.RWMutex
+	source       Source = StaticSource{}
+)
+
+// SetSource sets where the permissions of the roles come from
+func SetSource(s Source) {
+	sourceRWLock.Lock()
+	defer sourceRWLock.Unlock()
+	source = s
+}
+
+func getSource() Source {
+	sourceRWLock.RLock()
+	defer sourceRWLock.RUnlock()
+	return source
+}
+
+var namer = schema.NamingStrategy{}
+
+// ResourceName returns the name of the resource in permissions: a string is the name itself
+func ResourceName(resource interface{}) string {
+	switch r := resource.(type) {
+	case string:
+		return r
+	case Resource:
+		return r.ResourceName()
+	}
+	t := reflect.TypeOf(resource)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return namer.TableName(t.Name())
+}
+
+// Permissions returns the permissions of the roles of the user, and the ones granted by the token permissions claim
+func Permissions(ctx context.Context, auth corecontainer.Auth) ([]string, error) {
+	permissions, err := getSource().Permissions(ctx, auth.Roles)
+	if err != nil {
+		return nil, err
+	}
+	return append(permissions, auth.Claims.Strings(PermissionsClaim)...), nil
+}
+
+// grant tells how a permission grants an action on a resource
+type grant int
+
+const (
+	grantNone grant = iota
+	grantOwn
+	grantAll
+)
+
+func matches(pattern string, value string) bool {
+	return pattern == Wildcard || pattern == value
+}
+
+func grantOf(permission string, action string, resourceName string) grant {
+	if permission == Wildcard {
+		return grantAll
+	}
+	parts := strings.Split(permission, ":")
+	if len(parts) < 2 || len(parts) > 3 || !matches(parts[0], action) || !matches(parts[1], resourceName) {
+		return grantNone
+	}
+	if len(parts) == 3 {
+		if parts[2] == Own {
+			return grantOwn
+		}
+		return grantNone
+	}
+	return grantAll
+}
+
+// decide returns the best grant of the user permissions for the action on the resource
+func decide(ctx context.Context, auth corecontainer.Auth, action string, resourceName string) (grant, error) {
+	permissions, err := Permissions(ctx, auth)
+	if err != nil {
+		return grantNone, err
+	}
+	res := grantNone
+	for _, permission := range permissions {
+		if g := grantOf(permission, action, resourceName); g > res {
+			res = g
+		}
+	}
+	return res, nil
+}
+
+func unauthenticated() error {
+	return CodeUnauthenticated.New("")
+}
+
+func missingPermission(action string, resourceName string) error {
+	return ryerr.AddFieldError(CodeMissingPermission.New(""), ryerr.FieldError{Field: "permission", Tag: "missing_permission", Param: action + ":" + resourceName, Message: "Missing permission " + action + ":" + resourceName})
+}
+
+func notOwner(action string, resourceName string) error {
+	return ryerr.AddFieldError(CodeNotOwner.New(""), ryerr.FieldError{Field: "permission", Tag: "not_owner", Param: action + ":" + resourceName + ":" + Own, Message: "Not the owner of the " + resourceName})
+}
+
+// HasPermission Check if the user is granted the permission "action:resource", fully or on their own resources only
+func HasPermission(ctx context.Context, auth corecontainer.Auth, permission string) (bool, error) {
+	action, resourceName, _ := strings.Cut(permission, ":")
+	g, err := decide(ctx, auth, action, resourceName)
+	return g != grantNone, err
+}
+
+// CheckPermission returns nil if the user is granted the action on the resource, fully or on their own resources only.
+// See Can for the errors
+func CheckPermission(ctx context.Context, auth corecontainer.Auth, action string, resourceName string) error {
+	if !auth.IsAuthenticated() {
+		return unauthenticated()
+	}
+	g, err := decide(ctx, auth, action, resourceName)
+	if err != nil {
+		return err
+	}
+	if g == grantNone {
+		return missingPermission(action, resourceName)
+	}
+	return nil
+}
+
+// Can returns nil if the user of the request can do the action on the resource, a ryerr error otherwise:
+// CodeUnauthenticated, CodeMissingPermission or CodeNotOwner (the reason is the error code of the response).
+// The resource is a resource name, or a model: with an "own" permission, the model must be Owned by the user
+func Can(r *corecontainer.Request, action string, resource interface{}) error {
+	return CanCtx(r.Ctx, r.GetAuth(), action, resource)
+}
+
+// CanCtx is Can for an Auth outside of a request, e.g. in a job
+func CanCtx(ctx context.Context, auth corecontainer.Auth, action string, resource interface{}) error {
+	if !auth.IsAuthenticated() {
+		return unauthenticated()
+	}
+	resourceName := ResourceName(resource)
+	g, err := decide(ctx, auth, action, resourceName)
+	if err != nil {
+		return err
+	}
+	switch g {
+	case grantAll:
+		return nil
+	case grantOwn:
+		if owned, ok := resource.(Owned); ok && owned.OwnerID() != "" && owned.OwnerID() == auth.UserID() {
+			return nil
+		}
+		return notOwner(action, resourceName)
+	}
+	return missingPermission(action, resourceName)
+}
+
+// OwnerScope returns a row scope for the repositories (see corerp.CoreGormRepository.WithRowScope):
+// users granted the action on every row of the resource see every row, users granted it on their own resources
+// only see the rows whose column holds their ID, and the others see no row
+func OwnerScope(column string, action string, resource interface{}) corerp.RowScope {
+	resourceName := ResourceName(resource)
+	return func(r corecontainer.RequestInf, db *gorm.DB) *gorm.DB {
+		ctx := context.Background()
+		var auth corecontainer.Auth
+		if request, ok := r.(*corecontainer.Request); ok {
+			auth = request.GetAuth()
+			if request.Ctx != nil {
+				ctx = request.Ctx
+			}
+		}
+		g, err := decide(ctx, auth, action, resourceName)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		switch {
+		case g == grantAll:
+			return db
+		case g == grantOwn && auth.UserID() != "":
+			return db.Where(db.Statement.Quote(column)+" = ?", auth.UserID())
+		}
+		return db.Where("1 = 0")
+	}
+}
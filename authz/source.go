@@ -0,0 +1,124 @@
+package authz
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rayyone/go-core/ryerr"
+	"gorm.io/gorm"
+)
+
+// Source resolves roles to permissions
+type Source interface {
+	Permissions(ctx context.Context, roles []string) ([]string, error)
+}
+
+// StaticSource maps each role to its permissions
+type StaticSource map[string][]string
+
+func (s StaticSource) Permissions(_ context.Context, roles []string) ([]string, error) {
+	var res []string
+	for _, role := range roles {
+		res = append(res, s[role]...)
+	}
+	return res, nil
+}
+
+type GormSourceOptions struct {
+	// Table default to "role_permissions"
+	Table string
+	// RoleColumn default to "role"
+	RoleColumn string
+	// PermissionColumn default to "permission"
+	PermissionColumn string
+	// CacheTTL caches the permissions of each role. 0 disables the cache
+	CacheTTL time.Duration
+}
+
+func DefaultGormSourceOptions() GormSourceOptions {
+	return GormSourceOptions{
+		Table:            "role_permissions",
+		RoleColumn:       "role",
+		PermissionColumn: "permission",
+		CacheTTL:         time.Minute,
+	}
+}
+
+type cachedPermissions struct {
+	permissions []string
+	expiresAt   time.Time
+}
+
+// GormSource reads the permissions of the roles from a table with a role and a permission column
+type GormSource struct {
+	db      *gorm.DB
+	options GormSourceOptions
+	lock    sync.Mutex
+	cache   map[string]cachedPermissions
+}
+
+func NewGormSource(db *gorm.DB, options GormSourceOptions) *GormSource {
+	defaults := DefaultGormSourceOptions()
+	if options.Table == "" {
+		options.Table = defaults.Table
+	}
+	if options.RoleColumn == "" {
+		options.RoleColumn = defaults.RoleColumn
+	}
+	if options.PermissionColumn == "" {
+		options.PermissionColumn = defaults.PermissionColumn
+	}
+	return &GormSource{db: db, options: options, cache: map[string]cachedPermissions{}}
+}
+
+func (s *GormSource) Permissions(ctx context.Context, roles []string) ([]string, error) {
+	var res []string
+	var missing []string
+	now := time.Now()
+	s.lock.Lock()
+	for _, role := range roles {
+		if cached, ok := s.cache[role]; ok && now.Before(cached.expiresAt) {
+			res = append(res, cached.permissions...)
+		} else {
+			missing = append(missing, role)
+		}
+	}
+	s.lock.Unlock()
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	var rows []struct {
+		Role       string
+		Permission string
+	}
+	err := s.db.WithContext(ctx).Table(s.options.Table).
+		Select(s.options.RoleColumn+" AS role, "+s.options.PermissionColumn+" AS permission").
+		Where(s.options.RoleColumn+" IN ?", missing).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, ryerr.Wrap(err, "Cannot load the role permissions")
+	}
+
+	loaded := make(map[string][]string, len(missing))
+	for _, row := range rows {
+		loaded[row.Role] = append(loaded[row.Role], row.Permission)
+		res = append(res, row.Permission)
+	}
+	if s.options.CacheTTL > 0 {
+		s.lock.Lock()
+		for _, role := range missing {
+			s.cache[role] = cachedPermissions{permissions: loaded[role], expiresAt: now.Add(s.options.CacheTTL)}
+		}
+		s.lock.Unlock()
+	}
+	return res, nil
+}
+
+// ClearCache forgets the cached permissions, e.g. after the table has been changed
+func (s *GormSource) ClearCache() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cache = map[string]cachedPermissions{}
+}
@@ -0,0 +1,123 @@
+package corecontainer
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rayyone/go-core/helpers/jwt"
+)
+
+// AuthGinKey is the gin context key of the Auth, see middleware.Authenticate
+const AuthGinKey = "auth"
+
+type authCtxKey struct{}
+
+// Auth is the authenticated principal of a request. The zero value is an unauthenticated request
+type Auth struct {
+	Subject string
+	Scopes  []string
+	// Roles are read from the "roles" and "role" claims, see the authz package
+	Roles  []string
+	Claims jwt.Claims
+}
+
+// NewAuth builds the Auth of verified token claims
+func NewAuth(claims jwt.Claims) Auth {
+	return Auth{
+		Subject: claims.Subject(),
+		Scopes:  claims.Scopes(),
+		Roles:   append(claims.Strings("roles"), claims.Strings("role")...),
+		Claims:  claims,
+	}
+}
+
+// IsAuthenticated Check if the request carries a verified token
+func (a Auth) IsAuthenticated() bool {
+	return a.Claims != nil
+}
+
+// UserID returns the subject of the token
+func (a Auth) UserID() string {
+	return a.Subject
+}
+
+// HasScope Check if the token grants the scope
+func (a Auth) HasScope(scope string) bool {
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllScopes Check if the token grants every scope
+func (a Auth) HasAllScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !a.HasScope(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// Claim returns a claim of the token
+func (a Auth) Claim(key string) (interface{}, bool) {
+	value, ok := a.Claims[key]
+	return value, ok
+}
+
+// WithAuth returns a context holding the Auth
+func WithAuth(ctx context.Context, auth Auth) context.Context {
+	return context.WithValue(ctx, authCtxKey{}, auth)
+}
+
+// AuthFromContext returns the Auth held in the context, or the gin context. The zero Auth if there is none
+func AuthFromContext(ctx context.Context) Auth {
+	if ctx == nil {
+		return Auth{}
+	}
+	if auth, ok := ctx.Value(authCtxKey{}).(Auth); ok {
+		return auth
+	}
+	if auth, ok := ctx.Value(AuthGinKey).(Auth); ok {
+		return auth
+	}
+	return Auth{}
+}
+
+// GetAuth returns the Auth of the gin request
+func GetAuth(c *gin.Context) Auth {
+	if c == nil {
+		return Auth{}
+	}
+	if auth, ok := c.Get(AuthGinKey); ok {
+		if auth, ok := auth.(Auth); ok {
+			return auth
+		}
+	}
+	if c.Request != nil {
+		return AuthFromContext(c.Request.Context())
+	}
+	return Auth{}
+}
+
+// GetAuth returns the Auth of the request: the one set on the request, or the one of its contexts
+func (r *Request) GetAuth() Auth {
+	if r.Auth.IsAuthenticated() {
+		return r.Auth
+	}
+	if auth := AuthFromContext(r.Ctx); auth.IsAuthenticated() {
+		return auth
+	}
+	return GetAuth(r.GinCtx)
+}
+
+// LoadAuth sets the Auth of the request from its gin context or its context, see middleware.Authenticate.
+// InitCoreRequest calls it
+func (r *Request) LoadAuth() {
+	r.Auth = GetAuth(r.GinCtx)
+	if !r.Auth.IsAuthenticated() {
+		r.Auth = AuthFromContext(r.Ctx)
+	}
+}
@@ -2,6 +2,12 @@ package corecontainer
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rayyone/go-core/helpers/retry"
 	"github.com/rayyone/go-core/ryerr"
 	"gorm.io/gorm"
 )
@@ -10,8 +16,43 @@ type Database struct {
 	db                *gorm.DB
 	dbTransaction     *gorm.DB
 	transactionOpened bool
+	// levels of the transactions, the outermost first, see BeginTransaction
+	levels []*txLevel
+}
+
+// txLevel is a transaction, or a nested transaction with its savepoint. It is removed from the levels once committed
+// or rolled back
+type txLevel struct {
+	savepoint string
+	hooks     txHooks
+}
+
+// Tx is a transaction begun by BeginTransaction or BeginTx. Its Commit and Rollback apply to this transaction only,
+// and its Rollback does nothing once it has been committed or rolled back
+type Tx struct {
+	db    *Database
+	level *txLevel
+}
+
+// Commit commits the transaction, see Database.Commit
+func (tx *Tx) Commit() error {
+	return tx.db.commitLevel(tx.level)
 }
 
+// Rollback rolls back the transaction and the ones nested in it, unless it has been committed or rolled back
+func (tx *Tx) Rollback() error {
+	return tx.db.rollbackLevel(tx.level)
+}
+
+type txHooks struct {
+	afterCommit   []func() error
+	afterRollback []func() error
+}
+
+// ErrHookFailed is in the chain of the errors of the after commit and rollback hooks. They are returned by Rollback,
+// and handled (logged & reported) after Commit, which succeeded nonetheless
+var ErrHookFailed = errors.New("transaction hook failed")
+
 func (d *Database) GetTx() *gorm.DB {
 	if !d.transactionOpened {
 		return d.db
@@ -19,46 +60,307 @@ func (d *Database) GetTx() *gorm.DB {
 	return d.dbTransaction
 }
 
-func (d *Database) BeginTransaction() {
-	d.dbTransaction = d.db.Begin()
+// BeginTransaction begins a transaction. If a transaction is already open, it begins a nested transaction with a savepoint:
+// its Commit releases the savepoint, its Rollback rolls back to the savepoint, and only the outermost Commit commits.
+// Database.Commit and Database.Rollback apply to the innermost open transaction. To defer the Rollback, use the one of
+// the returned Tx, which does nothing once the Tx is committed:
+//
+//	tx := r.DBM.BeginTransaction()
+//	defer tx.Rollback()
+//	...
+//	return tx.Commit()
+//
+// A failure to begin surfaces on the queries and the Commit, use BeginTx to get it
+func (d *Database) BeginTransaction() *Tx {
+	level, _ := d.begin()
+	return &Tx{db: d, level: level}
+}
+
+// BeginTx begins a transaction like BeginTransaction, and returns the error if it cannot begin, with nothing left open.
+// The options (isolation level, read only) only apply to the outermost transaction
+func (d *Database) BeginTx(opts ...*sql.TxOptions) (*Tx, error) {
+	level, err := d.beginTx(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{db: d, level: level}, nil
+}
+
+func (d *Database) beginTx(opts ...*sql.TxOptions) (*txLevel, error) {
+	level, err := d.begin(opts...)
+	if err != nil {
+		if len(d.levels) > 1 {
+			d.levels = d.levels[:len(d.levels)-1]
+		} else {
+			d.Clear()
+		}
+		return nil, err
+	}
+	return level, nil
+}
+
+// begin opens the transaction even if it fails, so the nested transaction never commits the outer one
+func (d *Database) begin(opts ...*sql.TxOptions) (*txLevel, error) {
+	if d.transactionOpened {
+		level := &txLevel{savepoint: fmt.Sprintf("sp%d", len(d.levels))}
+		d.levels = append(d.levels, level)
+		// A new session so a failure doesn't stick to the transaction
+		if err := d.dbTransaction.Session(&gorm.Session{}).SavePoint(level.savepoint).Error; err != nil {
+			return level, ryerr.Wrapf(err, "Cannot create savepoint %s", level.savepoint)
+		}
+		return level, nil
+	}
+	level := &txLevel{}
+	d.dbTransaction = d.db.Begin(opts...)
 	d.transactionOpened = true
+	d.levels = []*txLevel{level}
+	if err := d.dbTransaction.Error; err != nil {
+		return level, ryerr.Wrap(err, "Cannot begin transaction")
+	}
+	return level, nil
 }
 
+// Transaction runs fn in a transaction (nested in the open one if any, see BeginTransaction): it is committed if fn
+// returns nil, rolled back if fn returns an error or panics. The panic is not recovered
+func (d *Database) Transaction(fn func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	level, err := d.beginTx(opts...)
+	if err != nil {
+		return err
+	}
+	done := false
+	defer func() {
+		if !done {
+			handleHookError(d.rollbackLevel(level))
+		}
+	}()
+
+	err = fn(d.GetTx())
+	done = true
+	if err != nil {
+		handleHookError(d.rollbackLevel(level))
+		return err
+	}
+	return d.commitLevel(level)
+}
+
+// current returns the innermost open transaction, nil if there is none
+func (d *Database) current() *txLevel {
+	if !d.transactionOpened || len(d.levels) == 0 {
+		return nil
+	}
+	return d.levels[len(d.levels)-1]
+}
+
+func (d *Database) indexOf(level *txLevel) int {
+	for i, l := range d.levels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// AfterCommit queues fn to run once the open transaction is committed, after the hooks queued before it.
+// The hooks of a nested transaction run when the outermost one commits, and are dropped if it rolls back.
+// Without open transaction, fn runs immediately and its error is returned
+func (d *Database) AfterCommit(fn func() error) error {
+	level := d.current()
+	if level == nil {
+		return fn()
+	}
+	level.hooks.afterCommit = append(level.hooks.afterCommit, fn)
+	return nil
+}
+
+// AfterRollback queues fn to run once the open transaction is rolled back, after the hooks queued before it.
+// The hooks of a nested transaction run when it is rolled back to its savepoint, or when the outermost one rolls back.
+// Without open transaction, fn runs immediately and its error is returned
+func (d *Database) AfterRollback(fn func() error) error {
+	level := d.current()
+	if level == nil {
+		return fn()
+	}
+	level.hooks.afterRollback = append(level.hooks.afterRollback, fn)
+	return nil
+}
+
+// runHooks runs every hook, even if one fails, and returns their errors
+func runHooks(hooks []func() error) error {
+	var errs []error
+	for _, hook := range hooks {
+		if err := hook(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return ryerr.Wrap(fmt.Errorf("%w: %w", ErrHookFailed, errors.Join(errs...)), "Transaction hook error")
+}
+
+// handleHookError handles (log & report) the hook errors that can't be returned: those of a commit, which succeeded,
+// and of a rollback whose error isn't returned
+func handleHookError(err error) {
+	if errors.Is(err, ErrHookFailed) {
+		ryerr.Handle(err)
+	}
+}
+
+// DefaultTxRetryOptions retry the serialization failures and deadlocks 3 times, after ~50ms, ~100ms and ~200ms
+func DefaultTxRetryOptions() retry.Options {
+	return retry.Options{
+		DelayBetweenAttempt: 50 * time.Millisecond,
+		MaxRetry:            3,
+		Multiplier:          2,
+		Jitter:              0.5,
+		ShouldRetry:         ryerr.IsRetryableTransaction,
+	}
+}
+
+// TransactionWithRetry runs fn in a transaction like Transaction, and runs it again in a new transaction when it fails
+// with an error retryable by retryOptions (default to ryerr.IsRetryableTransaction). fn must be safe to run again.
+// Nested in an open transaction, fn runs once: the outer transaction has to be retried as a whole
+func (d *Database) TransactionWithRetry(fn func(tx *gorm.DB) error, retryOptions retry.Options, opts ...*sql.TxOptions) error {
+	if d.transactionOpened {
+		return d.Transaction(fn, opts...)
+	}
+	if retryOptions.ShouldRetry == nil {
+		retryOptions.ShouldRetry = ryerr.IsRetryableTransaction
+	}
+	if retryOptions.Ctx == nil {
+		retryOptions.Ctx = d.Context()
+	}
+	return retry.WithRetry(func() error {
+		return d.Transaction(fn, opts...)
+	}, retryOptions)
+}
+
+// TransactionDepth returns the number of open transactions, nested ones included
+func (d *Database) TransactionDepth() int {
+	if !d.transactionOpened {
+		return 0
+	}
+	return len(d.levels)
+}
+
+// SetContext sets the context of the queries, those of the open transaction included
 func (d *Database) SetContext(ctx context.Context) {
+	if d.db == nil {
+		return
+	}
 	d.db = d.db.WithContext(ctx)
+	if d.transactionOpened {
+		d.dbTransaction = d.dbTransaction.WithContext(ctx)
+	}
 }
 
+// Context returns the context of the queries
+func (d *Database) Context() context.Context {
+	if d.db == nil || d.db.Statement.Context == nil {
+		return context.Background()
+	}
+	return d.db.Statement.Context
+}
+
+// Commit commits the innermost open transaction, see BeginTransaction. The errors of the after commit hooks are handled,
+// not returned
 func (d *Database) Commit() error {
-	if !d.transactionOpened {
+	level := d.current()
+	if level == nil {
+		return ryerr.New("TX has been committed or rolled back")
+	}
+	return d.commitLevel(level)
+}
+
+// Rollback rolls back the innermost open transaction, see BeginTransaction
+func (d *Database) Rollback() error {
+	level := d.current()
+	if level == nil {
+		// TX has been committed or rolled back
+		return nil
+	}
+	return d.rollbackLevel(level)
+}
+
+func (d *Database) commitLevel(level *txLevel) error {
+	i := d.indexOf(level)
+	if i < 0 || !d.transactionOpened {
 		return ryerr.New("TX has been committed or rolled back")
 	}
+	// The nested transactions left open are committed with this one
+	for _, inner := range d.levels[i+1:] {
+		level.hooks.afterCommit = append(level.hooks.afterCommit, inner.hooks.afterCommit...)
+		level.hooks.afterRollback = append(level.hooks.afterRollback, inner.hooks.afterRollback...)
+	}
+
+	if i > 0 {
+		d.levels = d.levels[:i]
+		// The hooks now depend on the outer transaction
+		parent := d.levels[i-1]
+		parent.hooks.afterCommit = append(parent.hooks.afterCommit, level.hooks.afterCommit...)
+		parent.hooks.afterRollback = append(parent.hooks.afterRollback, level.hooks.afterRollback...)
+		err := d.dbTransaction.Exec("RELEASE SAVEPOINT " + level.savepoint).Error
+		if err != nil {
+			return ryerr.Newf("Release Savepoint Error. Error: %v", err)
+		}
+		return nil
+	}
 	err := d.dbTransaction.Commit().Error
 	if err != nil {
-		err = ryerr.Newf("Commit Error. Error: %v", err)
-		_ = d.Rollback()
+		err = ryerr.Wrap(err, "Commit Error")
+		handleHookError(d.rollbackLevel(level))
 		return err
 	}
+	hooks := level.hooks
 	d.Clear()
+	// The transaction is committed: the hook errors are not returned, so the callers don't run it again
+	handleHookError(runHooks(hooks.afterCommit))
 	return nil
 }
 
-func (d *Database) Rollback() error {
-	if !d.transactionOpened {
-		// TX has been committed or rolled back
+// rollbackLevel rolls back the transaction and the ones nested in it. It does nothing if it has been committed or
+// rolled back
+func (d *Database) rollbackLevel(level *txLevel) error {
+	i := d.indexOf(level)
+	if i < 0 || !d.transactionOpened {
 		return nil
 	}
+	var afterRollback []func() error
+	for _, l := range d.levels[i:] {
+		afterRollback = append(afterRollback, l.hooks.afterRollback...)
+	}
+
+	if i > 0 {
+		d.levels = d.levels[:i]
+		err := d.dbTransaction.Session(&gorm.Session{}).RollbackTo(level.savepoint).Error
+		if err != nil {
+			err = ryerr.Newf("Rollback To Savepoint Error. Error: %v", err)
+		}
+		return joinErrors(err, runHooks(afterRollback))
+	}
 	err := d.dbTransaction.Rollback().Error
+	// The transaction is over even if the rollback fails (e.g. after a failed commit), so the next one isn't nested in it
+	d.Clear()
 	if err != nil {
 		err = ryerr.Newf("Rollback Error. Error: %v", err)
+	}
+	return joinErrors(err, runHooks(afterRollback))
+}
+
+func joinErrors(err error, hookErr error) error {
+	if err == nil {
+		return hookErr
+	}
+	if hookErr == nil {
 		return err
 	}
-	d.Clear()
-	return nil
+	return errors.Join(err, hookErr)
 }
 
 func (d *Database) Clear() {
 	d.dbTransaction = nil
 	d.transactionOpened = false
+	d.levels = nil
 }
 
 func NewCoreDBManager(db *gorm.DB) *Database {
@@ -0,0 +1,241 @@
+package corecontainer
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rayyone/go-core/ryerr"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakeDriver records the statements of the transactions
+type fakeDriver struct {
+	lock       sync.Mutex
+	statements []string
+}
+
+func (f *fakeDriver) record(statement string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.statements = append(f.statements, statement)
+}
+
+func (f *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{f}, nil }
+
+// fakeConnector opens the fake connections without registering the driver
+type fakeConnector struct{ driver *fakeDriver }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{c.driver}, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return c.driver }
+
+type fakeConn struct{ driver *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.driver, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error) {
+	c.driver.record("BEGIN")
+	return fakeTx(c), nil
+}
+
+type fakeTx struct{ driver *fakeDriver }
+
+func (t fakeTx) Commit() error {
+	t.driver.record("COMMIT")
+	return nil
+}
+
+func (t fakeTx) Rollback() error {
+	t.driver.record("ROLLBACK")
+	return nil
+}
+
+type fakeStmt struct {
+	driver *fakeDriver
+	query  string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.driver.record(s.query)
+	return driver.RowsAffected(0), nil
+}
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func newTestDatabase(t *testing.T) (*Database, *fakeDriver) {
+	fake := &fakeDriver{}
+	sqlDB := sql.OpenDB(fakeConnector{fake})
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCoreDBManager(db), fake
+}
+
+func assertStatements(t *testing.T, fake *fakeDriver, expected ...string) {
+	t.Helper()
+	if got := strings.Join(fake.statements, "; "); got != strings.Join(expected, "; ") {
+		t.Errorf("statements = %q, expected %q", got, strings.Join(expected, "; "))
+	}
+}
+
+func TestNestedTransactionDeferredRollbackAfterCommit(t *testing.T) {
+	d, fake := newTestDatabase(t)
+	inner := func() error {
+		tx := d.BeginTransaction()
+		defer tx.Rollback()
+		return tx.Commit()
+	}
+	outer := func() error {
+		tx := d.BeginTransaction()
+		defer tx.Rollback()
+		if err := inner(); err != nil {
+			return err
+		}
+		if d.TransactionDepth() != 1 {
+			t.Errorf("depth = %d after the inner commit, expected 1", d.TransactionDepth())
+		}
+		return tx.Commit()
+	}
+
+	if err := outer(); err != nil {
+		t.Fatalf("outer commit failed: %v", err)
+	}
+	assertStatements(t, fake, "BEGIN", "SAVEPOINT sp1", "RELEASE SAVEPOINT sp1", "COMMIT")
+	if d.TransactionDepth() != 0 {
+		t.Errorf("depth = %d, expected 0", d.TransactionDepth())
+	}
+}
+
+func TestNestedTransactionRollback(t *testing.T) {
+	d, fake := newTestDatabase(t)
+	inner := func() error {
+		d.BeginTransaction()
+		defer d.Rollback()
+		return errors.New("inner failed")
+	}
+	outer := func() error {
+		d.BeginTransaction()
+		defer d.Rollback()
+		_ = inner()
+		return d.Commit()
+	}
+
+	if err := outer(); err != nil {
+		t.Fatalf("outer commit failed: %v", err)
+	}
+	assertStatements(t, fake, "BEGIN", "SAVEPOINT sp1", "ROLLBACK TO SAVEPOINT sp1", "COMMIT")
+}
+
+func TestNestedTransactionClosure(t *testing.T) {
+	d, fake := newTestDatabase(t)
+	err := d.Transaction(func(tx *gorm.DB) error {
+		if err := d.Transaction(func(tx *gorm.DB) error { return nil }); err != nil {
+			return err
+		}
+		return errors.New("outer failed")
+	})
+
+	if err == nil || err.Error() != "outer failed" {
+		t.Fatalf("err = %v, expected the error of the outer closure", err)
+	}
+	assertStatements(t, fake, "BEGIN", "SAVEPOINT sp1", "RELEASE SAVEPOINT sp1", "ROLLBACK")
+	if d.TransactionDepth() != 0 {
+		t.Errorf("depth = %d, expected 0", d.TransactionDepth())
+	}
+}
+
+func TestNestedCommitThenOuterRollback(t *testing.T) {
+	d, fake := newTestDatabase(t)
+	inner := func() error {
+		d.BeginTransaction()
+		return d.Commit()
+	}
+	outer := func() error {
+		d.BeginTransaction()
+		defer d.Rollback()
+		if err := inner(); err != nil {
+			return err
+		}
+		return errors.New("outer failed")
+	}
+
+	if err := outer(); err == nil {
+		t.Fatal("expected the error of the outer transaction")
+	}
+	assertStatements(t, fake, "BEGIN", "SAVEPOINT sp1", "RELEASE SAVEPOINT sp1", "ROLLBACK")
+	if d.TransactionDepth() != 0 || d.transactionOpened {
+		t.Errorf("depth = %d, opened = %v, expected the transaction to be over", d.TransactionDepth(), d.transactionOpened)
+	}
+}
+
+func TestNestedClosureThenOuterRollback(t *testing.T) {
+	d, fake := newTestDatabase(t)
+	outer := func() error {
+		tx := d.BeginTransaction()
+		defer tx.Rollback()
+		if err := d.Transaction(func(tx *gorm.DB) error { return nil }); err != nil {
+			return err
+		}
+		return errors.New("outer failed")
+	}
+
+	if err := outer(); err == nil {
+		t.Fatal("expected the error of the outer transaction")
+	}
+	assertStatements(t, fake, "BEGIN", "SAVEPOINT sp1", "RELEASE SAVEPOINT sp1", "ROLLBACK")
+	if d.TransactionDepth() != 0 || d.transactionOpened {
+		t.Errorf("depth = %d, opened = %v, expected the transaction to be over", d.TransactionDepth(), d.transactionOpened)
+	}
+}
+
+type recordingReporter struct {
+	events []*ryerr.Event
+}
+
+func (r *recordingReporter) Name() string { return "recording" }
+
+func (r *recordingReporter) Report(event *ryerr.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestCommitHookErrorIsReported(t *testing.T) {
+	reporters := ryerr.GetReporters()
+	ryerr.ClearReporters()
+	recorder := &recordingReporter{}
+	ryerr.RegisterReporter(recorder)
+	defer func() {
+		ryerr.ClearReporters()
+		for _, reporter := range reporters {
+			ryerr.RegisterReporter(reporter)
+		}
+	}()
+
+	d, _ := newTestDatabase(t)
+	d.BeginTransaction()
+	defer d.Rollback()
+	_ = d.AfterCommit(func() error { return errors.New("hook failed") })
+	if err := d.Commit(); err != nil {
+		t.Fatalf("commit returned the hook error: %v", err)
+	}
+	if len(recorder.events) != 1 {
+		t.Errorf("%d events reported, expected the hook error", len(recorder.events))
+	}
+}
+
+func TestAfterRollbackWithoutTransaction(t *testing.T) {
+	d, _ := newTestDatabase(t)
+	hookErr := errors.New("hook failed")
+	if err := d.AfterRollback(func() error { return hookErr }); err != hookErr {
+		t.Errorf("AfterRollback returned %v, expected the hook error", err)
+	}
+}
@@ -2,6 +2,7 @@ package corecontainer
 
 import (
 	"context"
+	"database/sql"
 	"mime/multipart"
 	"strconv"
 	"strings"
@@ -13,10 +14,11 @@ import (
 	"github.com/rayyone/go-core/helpers/array"
 	"github.com/rayyone/go-core/helpers/method"
 	"github.com/rayyone/go-core/helpers/pagination"
+	"github.com/rayyone/go-core/helpers/requestid"
 	"github.com/rayyone/go-core/ryerr"
+	"gorm.io/gorm"
 )
 
-type Auth struct{}
 type ExtraData struct{}
 
 type UrlParams struct {
@@ -46,13 +48,36 @@ func (r *Request) GetDBM() *Database {
 	return r.DBM
 }
 
+// RequestID returns the ID of the request, see middleware.RequestID
+func (r *Request) RequestID() string {
+	if id := requestid.FromContext(r.Ctx); id != "" {
+		return id
+	}
+	if r.GinCtx != nil {
+		return r.GinCtx.GetString(requestid.GinKey)
+	}
+	return ""
+}
+
+// InTransaction runs fn in a transaction of the request database, see Database.Transaction. The repositories called
+// with the request inside fn run in the transaction
+func (r *Request) InTransaction(fn func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	return r.DBM.Transaction(fn, opts...)
+}
+
+// InTransactionWithRetry runs fn in a transaction of the request database, again if it fails with a serialization failure
+// or a deadlock, see Database.TransactionWithRetry
+func (r *Request) InTransactionWithRetry(fn func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	return r.DBM.TransactionWithRetry(fn, DefaultTxRetryOptions(), opts...)
+}
+
 func (r *Request) SetQueryParams(params interface{}) error {
 	if params == nil {
 		return nil
 	}
 	err := r.GinCtx.ShouldBindQuery(params)
 	if err != nil {
-		_ = r.GinCtx.Error(err)
+		_ = r.GinCtx.Error(err).SetType(gin.ErrorTypeBind)
 		return err
 	}
 
@@ -80,7 +105,7 @@ func (r *Request) SetPostParams(params interface{}) error {
 		case *json.UnmarshalTypeError:
 			err = ryerr.Validation.New(err.Error())
 		}
-		_ = r.GinCtx.Error(err)
+		_ = r.GinCtx.Error(err).SetType(gin.ErrorTypeBind)
 		return err
 	}
 
@@ -109,7 +134,13 @@ func InitCoreRequest(c *gin.Context) *Request {
 	var r Request
 	r.GinCtx = c
 	r.Ctx = context.Background()
+	// The queries of the request are cancelled when the client disconnects or its deadline passes, see middleware.Timeout
+	if c != nil && c.Request != nil {
+		r.Ctx = c.Request.Context()
+	}
 	r.DBM = NewCoreDBManager(database.GetDB())
+	r.DBM.SetContext(r.Ctx)
+	r.LoadAuth()
 	initUrlParams(c, &r)
 	initPagination(c, &r)
 	return &r
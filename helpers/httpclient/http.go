@@ -2,6 +2,7 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -18,6 +19,7 @@ import (
 	"github.com/rayyone/go-core/ryerr"
 	"github.com/rayyone/go-core/helpers/httpclient/contenttype"
 	loghelper "github.com/rayyone/go-core/helpers/log"
+	"github.com/rayyone/go-core/helpers/requestid"
 	"github.com/rayyone/go-core/helpers/retry"
 )
 
@@ -26,6 +28,8 @@ var (
 	httpClient *http.Client
 )
 
+var logger = loghelper.Named("httpclient")
+
 // RequestOption Function to change request options
 type RequestOption func(*requestOptions)
 
@@ -38,6 +42,7 @@ type requestOptions struct {
 	HTTPClientTimeout    time.Duration
 	Debug                bool
 	RetryOptions         retry.Options
+	Ctx                  context.Context
 }
 
 func getDefaultRequestOptions() requestOptions {
@@ -119,6 +124,14 @@ func DontReportOnRequestError() RequestOption {
 	}
 }
 
+// Context set the context of the request, e.g. corecontainer.Request.Ctx: the call is cancelled with it and isn't retried
+// once it is done. Errors are reported with the scope held in this context
+func Context(ctx context.Context) RequestOption {
+	return func(o *requestOptions) {
+		o.Ctx = ctx
+	}
+}
+
 // WithRetry with retry
 func WithRetry(retryOptions retry.Options) RequestOption {
 	return func(o *requestOptions) {
@@ -257,11 +270,14 @@ func buildRequest(method string, url string, bodyParams io.Reader, opts ...Reque
 	}
 
 	if options.Debug {
-		loghelper.PrintYellowf("[HTTP Client] Requesting %s - %s", method, url)
-		loghelper.PrintMagentaf("[HTTP Client] Body Params: %v", bodyParams)
+		loghelper.WithContext(options.Ctx, logger).InfoContext(options.Ctx, "Requesting", "method", method, "url", url, "body_params", fmt.Sprintf("%v", bodyParams))
 	}
 
-	req, err := http.NewRequest(method, url, bodyParams)
+	ctx := options.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyParams)
 	if err != nil {
 		errMsg := fmt.Sprintf("Error: API Call - Cannot init HTTP Request to '%s'. Error: %v", url, err)
 		return nil, ryerr.BadRequest.New(errMsg)
@@ -284,6 +300,19 @@ func SendRequest(req *http.Request, result interface{}, opts ...RequestOption) (
 	for headerKey, headerValue := range options.Headers {
 		req.Header.Set(headerKey, headerValue)
 	}
+	if options.Ctx != nil {
+		req = req.WithContext(options.Ctx)
+	}
+	// The context of the request, the one of Context() if set, so the extras and logs stay in the request scope
+	ctx := req.Context()
+	// Stop retrying once the request is canceled, the retried errors are still decided by ShouldRetry
+	if options.RetryOptions.Ctx == nil {
+		options.RetryOptions.Ctx = ctx
+	}
+	// Forward the ID of the request being served, so the call can be correlated with it
+	if id := requestid.FromContext(ctx); id != "" && req.Header.Get(requestid.Header) == "" {
+		req.Header.Set(requestid.Header, id)
+	}
 
 	var bodyBs []byte
 	var resp *http.Response
@@ -291,7 +320,7 @@ func SendRequest(req *http.Request, result interface{}, opts ...RequestOption) (
 		start := time.Now()
 		resp, err = client.Do(req)
 		if options.Debug {
-			loghelper.PrintYellowf("[HTTP Client] Request completed in %.2fs", time.Since(start).Seconds())
+			loghelper.WithContext(ctx, logger).InfoContext(ctx, "Request completed", "method", req.Method, "url", req.URL.String(), "duration", time.Since(start))
 		}
 		if resp != nil {
 			defer resp.Body.Close()
@@ -326,18 +355,18 @@ func SendRequest(req *http.Request, result interface{}, opts ...RequestOption) (
 			errType = ryerr.Validation
 		}
 
-		ryerr.SetExtra("json_response", body)
+		ryerr.SetExtraCtx(ctx, "json_response", body)
 		if options.ErrorResult != nil {
 			if err := json.Unmarshal(bodyBs, &options.ErrorResult); err != nil {
-				return errType.Newf("Error: API Call to '%s' - Cannot unmarshal error response. Error: %v. Response: %s", resp.Request.URL, err, body)
+				return errType.NewfCtx(ctx, "Error: API Call to '%s' - Cannot unmarshal error response. Error: %v. Response: %s", resp.Request.URL, err, body)
 			}
 		}
 		errMsg := "Error: API Call to '%s' - Returning status code of %d. Body Response: %s"
-		return errType.Newf(errMsg, resp.Request.URL, resp.StatusCode, body)
+		return errType.NewfCtx(ctx, errMsg, resp.Request.URL, resp.StatusCode, body)
 	}
 
 	if err := json.Unmarshal(bodyBs, &result); err != nil {
-		return ryerr.BadRequest.Newf("Error: API Call to '%s' - Cannot unmarshal response. Error: %v", resp.Request.URL, err)
+		return ryerr.BadRequest.NewfCtx(ctx, "Error: API Call to '%s' - Cannot unmarshal response. Error: %v", resp.Request.URL, err)
 	}
 
 	return nil
@@ -0,0 +1,270 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	English    = "en"
+	Vietnamese = "vi"
+	Japanese   = "ja"
+
+	// DefaultTag is the validation key used when a validator tag has no message
+	DefaultTag = "default"
+	// LocaleGinKey is the gin context key of the request locale, see SetLocale
+	LocaleGinKey = "locale"
+)
+
+type localeCtxKey struct{}
+
+// ValidationKey returns the catalog key of a validator tag
+func ValidationKey(tag string) string {
+	return "validation." + tag
+}
+
+// ErrorKey returns the catalog key of an application error code
+func ErrorKey(code string) string {
+	return "error." + code
+}
+
+// Catalog holds message templates per locale. Templates are interpolated with {name} params
+type Catalog struct {
+	lock          sync.RWMutex
+	defaultLocale string
+	messages      map[string]map[string]string
+}
+
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		defaultLocale: defaultLocale,
+		messages:      map[string]map[string]string{},
+	}
+}
+
+var defaultCatalog = NewCatalog(English)
+
+func init() {
+	defaultCatalog.RegisterMessages(English, englishMessages)
+	defaultCatalog.RegisterMessages(Vietnamese, vietnameseMessages)
+	defaultCatalog.RegisterMessages(Japanese, japaneseMessages)
+}
+
+// DefaultCatalog returns the catalog used by the package functions
+func DefaultCatalog() *Catalog {
+	return defaultCatalog
+}
+
+// Register adds a message template to the locale, replacing the existing one
+func (c *Catalog) Register(locale string, key string, template string) {
+	c.RegisterMessages(locale, map[string]string{key: template})
+}
+
+// RegisterMessages adds message templates to the locale, replacing the existing ones
+func (c *Catalog) RegisterMessages(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.messages[locale] == nil {
+		c.messages[locale] = map[string]string{}
+	}
+	for key, template := range messages {
+		c.messages[locale][key] = template
+	}
+}
+
+// SetDefaultLocale sets the locale used when a message has no translation in the requested locale
+func (c *Catalog) SetDefaultLocale(locale string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.defaultLocale = normalizeLocale(locale)
+}
+
+func (c *Catalog) DefaultLocale() string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.defaultLocale
+}
+
+// Locales returns the locales having messages
+func (c *Catalog) Locales() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	res := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		res = append(res, locale)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Lookup returns the template of the key in the locale, falling back to the base language ("vi" for "vi-VN")
+// and then to the default locale
+func (c *Catalog) Lookup(locale string, key string) (string, bool) {
+	locale = normalizeLocale(locale)
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	for _, l := range []string{locale, baseLanguage(locale), c.defaultLocale} {
+		if template, ok := c.messages[l][key]; ok {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// T translates the key in the locale and interpolates the params. Returns an empty string if the key has no message
+func (c *Catalog) T(locale string, key string, params map[string]string) string {
+	template, ok := c.Lookup(locale, key)
+	if !ok {
+		return ""
+	}
+	return Interpolate(template, params)
+}
+
+// MatchLocale returns the supported locale matching best an Accept-Language header value, or the default locale
+func (c *Catalog) MatchLocale(acceptLanguage string) string {
+	supported := map[string]bool{}
+	for _, locale := range c.Locales() {
+		supported[locale] = true
+	}
+	for _, locale := range parseAcceptLanguage(acceptLanguage) {
+		if supported[locale] {
+			return locale
+		}
+		if base := baseLanguage(locale); supported[base] {
+			return base
+		}
+	}
+	return c.DefaultLocale()
+}
+
+// Interpolate replaces the {name} placeholders of the template with the params
+func Interpolate(template string, params map[string]string) string {
+	if len(params) == 0 {
+		return template
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// Register adds a message template to the default catalog. Use ValidationKey for custom validator tags
+func Register(locale string, key string, template string) {
+	defaultCatalog.Register(locale, key, template)
+}
+
+// RegisterMessages adds message templates to the default catalog
+func RegisterMessages(locale string, messages map[string]string) {
+	defaultCatalog.RegisterMessages(locale, messages)
+}
+
+// SetDefaultLocale sets the default locale of the default catalog
+func SetDefaultLocale(locale string) {
+	defaultCatalog.SetDefaultLocale(locale)
+}
+
+// T translates the key with the default catalog
+func T(locale string, key string, params map[string]string) string {
+	return defaultCatalog.T(locale, key, params)
+}
+
+// Lookup returns the template of the key with the default catalog
+func Lookup(locale string, key string) (string, bool) {
+	return defaultCatalog.Lookup(locale, key)
+}
+
+// MatchLocale matches an Accept-Language header value with the default catalog
+func MatchLocale(acceptLanguage string) string {
+	return defaultCatalog.MatchLocale(acceptLanguage)
+}
+
+// SetLocale sets the locale of the request, it takes precedence over the Accept-Language header
+func SetLocale(c *gin.Context, locale string) {
+	c.Set(LocaleGinKey, normalizeLocale(locale))
+}
+
+// GetLocale returns the locale of the request: the one set with SetLocale, or the best match of the Accept-Language header
+func GetLocale(c *gin.Context) string {
+	if c == nil {
+		return defaultCatalog.DefaultLocale()
+	}
+	if locale := c.GetString(LocaleGinKey); locale != "" {
+		return locale
+	}
+	if c.Request == nil {
+		return defaultCatalog.DefaultLocale()
+	}
+	return MatchLocale(c.GetHeader("Accept-Language"))
+}
+
+// WithLocale returns a context holding the locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeCtxKey{}, normalizeLocale(locale))
+}
+
+// LocaleFromContext returns the locale held in the context, or the default locale
+func LocaleFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if locale, ok := ctx.Value(localeCtxKey{}).(string); ok {
+			return locale
+		}
+		if locale, ok := ctx.Value(LocaleGinKey).(string); ok && locale != "" {
+			return locale
+		}
+	}
+	return defaultCatalog.DefaultLocale()
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func baseLanguage(locale string) string {
+	if i := strings.Index(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// parseAcceptLanguage returns the locales of an Accept-Language header value ordered by quality
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := normalizeLocale(fields[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			items = append(items, weighted{locale: locale, quality: quality})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].quality > items[j].quality
+	})
+	res := make([]string, len(items))
+	for i, item := range items {
+		res[i] = item.locale
+	}
+	return res
+}
@@ -0,0 +1,154 @@
+package i18n
+
+// englishMessages English messages of the go-playground/validator built-in tags
+var englishMessages = map[string]string{
+	ValidationKey(DefaultTag): "{field} is not valid",
+
+	// Fields
+	ValidationKey("required"):             "{field} is required",
+	ValidationKey("required_if"):          "{field} is required when {param}",
+	ValidationKey("required_unless"):      "{field} is required unless {param}",
+	ValidationKey("required_with"):        "{field} is required when {param} is present",
+	ValidationKey("required_with_all"):    "{field} is required when all of {param} are present",
+	ValidationKey("required_without"):     "{field} is required when {param} is not present",
+	ValidationKey("required_without_all"): "{field} is required when none of {param} are present",
+	ValidationKey("excluded_with"):        "{field} must be empty when {param} is present",
+	ValidationKey("excluded_with_all"):    "{field} must be empty when all of {param} are present",
+	ValidationKey("excluded_without"):     "{field} must be empty when {param} is not present",
+	ValidationKey("excluded_without_all"): "{field} must be empty when none of {param} are present",
+	ValidationKey("isdefault"):            "{field} must be empty",
+	ValidationKey("unique"):               "{field} must contain unique values",
+
+	// Comparisons
+	ValidationKey("len"):   "{field} must be {param} characters long",
+	ValidationKey("min"):   "{field} must be longer than or equal to {param}",
+	ValidationKey("max"):   "{field} cannot be longer than or equal to {param}",
+	ValidationKey("eq"):    "{field} must be equal to {param}",
+	ValidationKey("ne"):    "{field} must not be equal to {param}",
+	ValidationKey("lt"):    "{field} must be less than {param}",
+	ValidationKey("lte"):   "{field} must be less than or equal to {param}",
+	ValidationKey("gt"):    "{field} must be greater than {param}",
+	ValidationKey("gte"):   "{field} must be greater than or equal to {param}",
+	ValidationKey("oneof"): "{field} must be one of [{param}]",
+
+	// Cross field
+	ValidationKey("eqfield"):       "{field} must be equal to {param}",
+	ValidationKey("eqcsfield"):     "{field} must be equal to {param}",
+	ValidationKey("nefield"):       "{field} must not be equal to {param}",
+	ValidationKey("necsfield"):     "{field} must not be equal to {param}",
+	ValidationKey("gtfield"):       "{field} must be greater than {param}",
+	ValidationKey("gtcsfield"):     "{field} must be greater than {param}",
+	ValidationKey("gtefield"):      "{field} must be greater than or equal to {param}",
+	ValidationKey("gtecsfield"):    "{field} must be greater than or equal to {param}",
+	ValidationKey("ltfield"):       "{field} must be less than {param}",
+	ValidationKey("ltcsfield"):     "{field} must be less than {param}",
+	ValidationKey("ltefield"):      "{field} must be less than or equal to {param}",
+	ValidationKey("ltecsfield"):    "{field} must be less than or equal to {param}",
+	ValidationKey("fieldcontains"): "{field} must contain the value of {param}",
+	ValidationKey("fieldexcludes"): "{field} must not contain the value of {param}",
+
+	// Strings
+	ValidationKey("alpha"):           "{field} can only contain alphabetic characters",
+	ValidationKey("alphanum"):        "{field} can only contain alphanumeric characters",
+	ValidationKey("alphaunicode"):    "{field} can only contain unicode alphabetic characters",
+	ValidationKey("alphanumunicode"): "{field} can only contain unicode alphanumeric characters",
+	ValidationKey("ascii"):           "{field} can only contain ascii characters",
+	ValidationKey("printascii"):      "{field} can only contain printable ascii characters",
+	ValidationKey("multibyte"):       "{field} must contain multibyte characters",
+	ValidationKey("lowercase"):       "{field} must be a lowercase string",
+	ValidationKey("uppercase"):       "{field} must be an uppercase string",
+	ValidationKey("contains"):        "{field} must contain the text '{param}'",
+	ValidationKey("containsany"):     "{field} must contain at least one of the following characters '{param}'",
+	ValidationKey("containsrune"):    "{field} must contain the character '{param}'",
+	ValidationKey("excludes"):        "{field} cannot contain the text '{param}'",
+	ValidationKey("excludesall"):     "{field} cannot contain any of the following characters '{param}'",
+	ValidationKey("excludesrune"):    "{field} cannot contain the character '{param}'",
+	ValidationKey("startswith"):      "{field} must start with '{param}'",
+	ValidationKey("endswith"):        "{field} must end with '{param}'",
+	ValidationKey("startsnotwith"):   "{field} must not start with '{param}'",
+	ValidationKey("endsnotwith"):     "{field} must not end with '{param}'",
+
+	// Formats
+	ValidationKey("boolean"):       "{field} must be a boolean",
+	ValidationKey("numeric"):       "{field} must be a numeric value",
+	ValidationKey("number"):        "{field} must be a number",
+	ValidationKey("hexadecimal"):   "{field} must be a hexadecimal",
+	ValidationKey("hexcolor"):      "{field} must be a HEX color",
+	ValidationKey("rgb"):           "{field} must be a RGB color",
+	ValidationKey("rgba"):          "{field} must be a RGBA color",
+	ValidationKey("hsl"):           "{field} must be a HSL color",
+	ValidationKey("hsla"):          "{field} must be a HSLA color",
+	ValidationKey("iscolor"):       "{field} must be a color",
+	ValidationKey("e164"):          "{field} must be a E.164 formatted phone number",
+	ValidationKey("email"):         "Invalid email format",
+	ValidationKey("url"):           "{field} must be a valid URL",
+	ValidationKey("uri"):           "{field} must be a valid URI",
+	ValidationKey("urn_rfc2141"):   "{field} must be a valid RFC 2141 URN",
+	ValidationKey("file"):          "{field} must be an existing file",
+	ValidationKey("dir"):           "{field} must be an existing directory",
+	ValidationKey("base64"):        "{field} must be a Base64 string",
+	ValidationKey("base64url"):     "{field} must be a Base64 URL string",
+	ValidationKey("datauri"):       "{field} must be a Data URI",
+	ValidationKey("html"):          "{field} must be HTML",
+	ValidationKey("html_encoded"):  "{field} must be HTML encoded",
+	ValidationKey("url_encoded"):   "{field} must be URL encoded",
+	ValidationKey("json"):          "{field} must be a valid JSON string",
+	ValidationKey("jwt"):           "{field} must be a valid JWT",
+	ValidationKey("datetime"):      "{field} does not match the {param} format",
+	ValidationKey("timezone"):      "{field} must be a valid timezone",
+	ValidationKey("isbn"):          "{field} must be a valid ISBN",
+	ValidationKey("isbn10"):        "{field} must be a valid ISBN-10",
+	ValidationKey("isbn13"):        "{field} must be a valid ISBN-13",
+	ValidationKey("uuid"):          "{field} must be a valid UUID",
+	ValidationKey("uuid3"):         "{field} must be a valid version 3 UUID",
+	ValidationKey("uuid4"):         "{field} must be a valid version 4 UUID",
+	ValidationKey("uuid5"):         "{field} must be a valid version 5 UUID",
+	ValidationKey("uuid_rfc4122"):  "{field} must be a valid RFC 4122 UUID",
+	ValidationKey("uuid3_rfc4122"): "{field} must be a valid version 3 RFC 4122 UUID",
+	ValidationKey("uuid4_rfc4122"): "{field} must be a valid version 4 RFC 4122 UUID",
+	ValidationKey("uuid5_rfc4122"): "{field} must be a valid version 5 RFC 4122 UUID",
+	ValidationKey("ssn"):           "{field} must be a valid SSN",
+	ValidationKey("bic"):           "{field} must be a valid BIC",
+
+	// Crypto
+	ValidationKey("eth_addr"):        "{field} must be a valid Ethereum address",
+	ValidationKey("btc_addr"):        "{field} must be a valid Bitcoin address",
+	ValidationKey("btc_addr_bech32"): "{field} must be a valid Bech32 Bitcoin address",
+
+	// Geography & locales
+	ValidationKey("latitude"):                      "{field} must contain valid latitude coordinates",
+	ValidationKey("longitude"):                     "{field} must contain valid longitude coordinates",
+	ValidationKey("iso3166_1_alpha2"):              "{field} must be a valid ISO 3166-1 alpha-2 country code",
+	ValidationKey("iso3166_1_alpha3"):              "{field} must be a valid ISO 3166-1 alpha-3 country code",
+	ValidationKey("iso3166_1_alpha_numeric"):       "{field} must be a valid ISO 3166-1 numeric country code",
+	ValidationKey("iso3166_2"):                     "{field} must be a valid ISO 3166-2 subdivision code",
+	ValidationKey("country_code"):                  "{field} must be a valid country code",
+	ValidationKey("iso4217"):                       "{field} must be a valid currency code",
+	ValidationKey("iso4217_numeric"):               "{field} must be a valid numeric currency code",
+	ValidationKey("bcp47_language_tag"):            "{field} must be a valid BCP 47 language tag",
+	ValidationKey("postcode_iso3166_alpha2"):       "{field} must be a valid postcode of {param}",
+	ValidationKey("postcode_iso3166_alpha2_field"): "{field} must be a valid postcode of the country in {param}",
+
+	// Network
+	ValidationKey("ip"):               "{field} must be a valid IP address",
+	ValidationKey("ipv4"):             "{field} must be a valid IPv4 address",
+	ValidationKey("ipv6"):             "{field} must be a valid IPv6 address",
+	ValidationKey("cidr"):             "{field} must contain a valid CIDR notation",
+	ValidationKey("cidrv4"):           "{field} must contain a valid CIDR notation for an IPv4 address",
+	ValidationKey("cidrv6"):           "{field} must contain a valid CIDR notation for an IPv6 address",
+	ValidationKey("tcp_addr"):         "{field} must be a valid TCP address",
+	ValidationKey("tcp4_addr"):        "{field} must be a valid IPv4 TCP address",
+	ValidationKey("tcp6_addr"):        "{field} must be a valid IPv6 TCP address",
+	ValidationKey("udp_addr"):         "{field} must be a valid UDP address",
+	ValidationKey("udp4_addr"):        "{field} must be a valid IPv4 UDP address",
+	ValidationKey("udp6_addr"):        "{field} must be a valid IPv6 UDP address",
+	ValidationKey("ip_addr"):          "{field} must be a resolvable IP address",
+	ValidationKey("ip4_addr"):         "{field} must be a resolvable IPv4 address",
+	ValidationKey("ip6_addr"):         "{field} must be a resolvable IPv6 address",
+	ValidationKey("unix_addr"):        "{field} must be a resolvable UNIX address",
+	ValidationKey("mac"):              "{field} must contain a valid MAC address",
+	ValidationKey("hostname"):         "{field} must be a valid hostname",
+	ValidationKey("hostname_rfc1123"): "{field} must be a valid RFC 1123 hostname",
+	ValidationKey("hostname_port"):    "{field} must be a valid host:port",
+	ValidationKey("fqdn"):             "{field} must be a valid FQDN",
+}
@@ -0,0 +1,154 @@
+package i18n
+
+// japaneseMessages Japanese messages of the go-playground/validator built-in tags
+var japaneseMessages = map[string]string{
+	ValidationKey(DefaultTag): "{field}は無効です",
+
+	// Fields
+	ValidationKey("required"):             "{field}は必須です",
+	ValidationKey("required_if"):          "{param}の場合、{field}は必須です",
+	ValidationKey("required_unless"):      "{param}でない場合、{field}は必須です",
+	ValidationKey("required_with"):        "{param}がある場合、{field}は必須です",
+	ValidationKey("required_with_all"):    "{param}がすべてある場合、{field}は必須です",
+	ValidationKey("required_without"):     "{param}がない場合、{field}は必須です",
+	ValidationKey("required_without_all"): "{param}がいずれもない場合、{field}は必須です",
+	ValidationKey("excluded_with"):        "{param}がある場合、{field}は空である必要があります",
+	ValidationKey("excluded_with_all"):    "{param}がすべてある場合、{field}は空である必要があります",
+	ValidationKey("excluded_without"):     "{param}がない場合、{field}は空である必要があります",
+	ValidationKey("excluded_without_all"): "{param}がいずれもない場合、{field}は空である必要があります",
+	ValidationKey("isdefault"):            "{field}は空である必要があります",
+	ValidationKey("unique"):               "{field}は重複しない値である必要があります",
+
+	// Comparisons
+	ValidationKey("len"):   "{field}は{param}文字である必要があります",
+	ValidationKey("min"):   "{field}は{param}以上である必要があります",
+	ValidationKey("max"):   "{field}は{param}以下である必要があります",
+	ValidationKey("eq"):    "{field}は{param}と等しい必要があります",
+	ValidationKey("ne"):    "{field}は{param}と異なる必要があります",
+	ValidationKey("lt"):    "{field}は{param}より小さい必要があります",
+	ValidationKey("lte"):   "{field}は{param}以下である必要があります",
+	ValidationKey("gt"):    "{field}は{param}より大きい必要があります",
+	ValidationKey("gte"):   "{field}は{param}以上である必要があります",
+	ValidationKey("oneof"): "{field}は[{param}]のいずれかである必要があります",
+
+	// Cross field
+	ValidationKey("eqfield"):       "{field}は{param}と等しい必要があります",
+	ValidationKey("eqcsfield"):     "{field}は{param}と等しい必要があります",
+	ValidationKey("nefield"):       "{field}は{param}と異なる必要があります",
+	ValidationKey("necsfield"):     "{field}は{param}と異なる必要があります",
+	ValidationKey("gtfield"):       "{field}は{param}より大きい必要があります",
+	ValidationKey("gtcsfield"):     "{field}は{param}より大きい必要があります",
+	ValidationKey("gtefield"):      "{field}は{param}以上である必要があります",
+	ValidationKey("gtecsfield"):    "{field}は{param}以上である必要があります",
+	ValidationKey("ltfield"):       "{field}は{param}より小さい必要があります",
+	ValidationKey("ltcsfield"):     "{field}は{param}より小さい必要があります",
+	ValidationKey("ltefield"):      "{field}は{param}以下である必要があります",
+	ValidationKey("ltecsfield"):    "{field}は{param}以下である必要があります",
+	ValidationKey("fieldcontains"): "{field}は{param}の値を含む必要があります",
+	ValidationKey("fieldexcludes"): "{field}は{param}の値を含めることはできません",
+
+	// Strings
+	ValidationKey("alpha"):           "{field}は英字のみ使用できます",
+	ValidationKey("alphanum"):        "{field}は英数字のみ使用できます",
+	ValidationKey("alphaunicode"):    "{field}はunicode文字のみ使用できます",
+	ValidationKey("alphanumunicode"): "{field}はunicode文字と数字のみ使用できます",
+	ValidationKey("ascii"):           "{field}はascii文字のみ使用できます",
+	ValidationKey("printascii"):      "{field}は印字可能なascii文字のみ使用できます",
+	ValidationKey("multibyte"):       "{field}はマルチバイト文字を含む必要があります",
+	ValidationKey("lowercase"):       "{field}は小文字である必要があります",
+	ValidationKey("uppercase"):       "{field}は大文字である必要があります",
+	ValidationKey("contains"):        "{field}は'{param}'を含む必要があります",
+	ValidationKey("containsany"):     "{field}は'{param}'の文字を少なくとも1つ含む必要があります",
+	ValidationKey("containsrune"):    "{field}は文字'{param}'を含む必要があります",
+	ValidationKey("excludes"):        "{field}に'{param}'を含めることはできません",
+	ValidationKey("excludesall"):     "{field}に'{param}'の文字を含めることはできません",
+	ValidationKey("excludesrune"):    "{field}に文字'{param}'を含めることはできません",
+	ValidationKey("startswith"):      "{field}は'{param}'で始まる必要があります",
+	ValidationKey("endswith"):        "{field}は'{param}'で終わる必要があります",
+	ValidationKey("startsnotwith"):   "{field}は'{param}'で始めることはできません",
+	ValidationKey("endsnotwith"):     "{field}は'{param}'で終えることはできません",
+
+	// Formats
+	ValidationKey("boolean"):       "{field}は真偽値である必要があります",
+	ValidationKey("numeric"):       "{field}は数値である必要があります",
+	ValidationKey("number"):        "{field}は数字である必要があります",
+	ValidationKey("hexadecimal"):   "{field}は16進数である必要があります",
+	ValidationKey("hexcolor"):      "{field}はHEXカラーである必要があります",
+	ValidationKey("rgb"):           "{field}はRGBカラーである必要があります",
+	ValidationKey("rgba"):          "{field}はRGBAカラーである必要があります",
+	ValidationKey("hsl"):           "{field}はHSLカラーである必要があります",
+	ValidationKey("hsla"):          "{field}はHSLAカラーである必要があります",
+	ValidationKey("iscolor"):       "{field}は有効な色である必要があります",
+	ValidationKey("e164"):          "{field}はE.164形式の電話番号である必要があります",
+	ValidationKey("email"):         "メールアドレスの形式が正しくありません",
+	ValidationKey("url"):           "{field}は有効なURLである必要があります",
+	ValidationKey("uri"):           "{field}は有効なURIである必要があります",
+	ValidationKey("urn_rfc2141"):   "{field}は有効なRFC 2141 URNである必要があります",
+	ValidationKey("file"):          "{field}は存在するファイルである必要があります",
+	ValidationKey("dir"):           "{field}は存在するディレクトリである必要があります",
+	ValidationKey("base64"):        "{field}はBase64文字列である必要があります",
+	ValidationKey("base64url"):     "{field}はBase64 URL文字列である必要があります",
+	ValidationKey("datauri"):       "{field}はData URIである必要があります",
+	ValidationKey("html"):          "{field}はHTMLである必要があります",
+	ValidationKey("html_encoded"):  "{field}はHTMLエンコードされている必要があります",
+	ValidationKey("url_encoded"):   "{field}はURLエンコードされている必要があります",
+	ValidationKey("json"):          "{field}は有効なJSON文字列である必要があります",
+	ValidationKey("jwt"):           "{field}は有効なJWTである必要があります",
+	ValidationKey("datetime"):      "{field}は{param}の形式と一致しません",
+	ValidationKey("timezone"):      "{field}は有効なタイムゾーンである必要があります",
+	ValidationKey("isbn"):          "{field}は有効なISBNである必要があります",
+	ValidationKey("isbn10"):        "{field}は有効なISBN-10である必要があります",
+	ValidationKey("isbn13"):        "{field}は有効なISBN-13である必要があります",
+	ValidationKey("uuid"):          "{field}は有効なUUIDである必要があります",
+	ValidationKey("uuid3"):         "{field}は有効なバージョン3のUUIDである必要があります",
+	ValidationKey("uuid4"):         "{field}は有効なバージョン4のUUIDである必要があります",
+	ValidationKey("uuid5"):         "{field}は有効なバージョン5のUUIDである必要があります",
+	ValidationKey("uuid_rfc4122"):  "{field}は有効なRFC 4122 UUIDである必要があります",
+	ValidationKey("uuid3_rfc4122"): "{field}は有効なバージョン3のRFC 4122 UUIDである必要があります",
+	ValidationKey("uuid4_rfc4122"): "{field}は有効なバージョン4のRFC 4122 UUIDである必要があります",
+	ValidationKey("uuid5_rfc4122"): "{field}は有効なバージョン5のRFC 4122 UUIDである必要があります",
+	ValidationKey("ssn"):           "{field}は有効なSSNである必要があります",
+	ValidationKey("bic"):           "{field}は有効なBICである必要があります",
+
+	// Crypto
+	ValidationKey("eth_addr"):        "{field}は有効なイーサリアムアドレスである必要があります",
+	ValidationKey("btc_addr"):        "{field}は有効なビットコインアドレスである必要があります",
+	ValidationKey("btc_addr_bech32"): "{field}は有効なBech32ビットコインアドレスである必要があります",
+
+	// Geography & locales
+	ValidationKey("latitude"):                      "{field}は有効な緯度である必要があります",
+	ValidationKey("longitude"):                     "{field}は有効な経度である必要があります",
+	ValidationKey("iso3166_1_alpha2"):              "{field}は有効なISO 3166-1 alpha-2国コードである必要があります",
+	ValidationKey("iso3166_1_alpha3"):              "{field}は有効なISO 3166-1 alpha-3国コードである必要があります",
+	ValidationKey("iso3166_1_alpha_numeric"):       "{field}は有効なISO 3166-1数字国コードである必要があります",
+	ValidationKey("iso3166_2"):                     "{field}は有効なISO 3166-2地域コードである必要があります",
+	ValidationKey("country_code"):                  "{field}は有効な国コードである必要があります",
+	ValidationKey("iso4217"):                       "{field}は有効な通貨コードである必要があります",
+	ValidationKey("iso4217_numeric"):               "{field}は有効な数字通貨コードである必要があります",
+	ValidationKey("bcp47_language_tag"):            "{field}は有効なBCP 47言語タグである必要があります",
+	ValidationKey("postcode_iso3166_alpha2"):       "{field}は{param}の有効な郵便番号である必要があります",
+	ValidationKey("postcode_iso3166_alpha2_field"): "{field}は{param}の国の有効な郵便番号である必要があります",
+
+	// Network
+	ValidationKey("ip"):               "{field}は有効なIPアドレスである必要があります",
+	ValidationKey("ipv4"):             "{field}は有効なIPv4アドレスである必要があります",
+	ValidationKey("ipv6"):             "{field}は有効なIPv6アドレスである必要があります",
+	ValidationKey("cidr"):             "{field}は有効なCIDR表記である必要があります",
+	ValidationKey("cidrv4"):           "{field}はIPv4アドレスの有効なCIDR表記である必要があります",
+	ValidationKey("cidrv6"):           "{field}はIPv6アドレスの有効なCIDR表記である必要があります",
+	ValidationKey("tcp_addr"):         "{field}は有効なTCPアドレスである必要があります",
+	ValidationKey("tcp4_addr"):        "{field}は有効なIPv4 TCPアドレスである必要があります",
+	ValidationKey("tcp6_addr"):        "{field}は有効なIPv6 TCPアドレスである必要があります",
+	ValidationKey("udp_addr"):         "{field}は有効なUDPアドレスである必要があります",
+	ValidationKey("udp4_addr"):        "{field}は有効なIPv4 UDPアドレスである必要があります",
+	ValidationKey("udp6_addr"):        "{field}は有効なIPv6 UDPアドレスである必要があります",
+	ValidationKey("ip_addr"):          "{field}は解決可能なIPアドレスである必要があります",
+	ValidationKey("ip4_addr"):         "{field}は解決可能なIPv4アドレスである必要があります",
+	ValidationKey("ip6_addr"):         "{field}は解決可能なIPv6アドレスである必要があります",
+	ValidationKey("unix_addr"):        "{field}は解決可能なUNIXアドレスである必要があります",
+	ValidationKey("mac"):              "{field}は有効なMACアドレスである必要があります",
+	ValidationKey("hostname"):         "{field}は有効なホスト名である必要があります",
+	ValidationKey("hostname_rfc1123"): "{field}は有効なRFC 1123ホスト名である必要があります",
+	ValidationKey("hostname_port"):    "{field}は有効なhost:portである必要があります",
+	ValidationKey("fqdn"):             "{field}は有効なFQDNである必要があります",
+}
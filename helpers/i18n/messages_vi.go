@@ -0,0 +1,154 @@
+package i18n
+
+// vietnameseMessages Vietnamese messages of the go-playground/validator built-in tags
+var vietnameseMessages = map[string]string{
+	ValidationKey(DefaultTag): "{field} không hợp lệ",
+
+	// Fields
+	ValidationKey("required"):             "{field} là bắt buộc",
+	ValidationKey("required_if"):          "{field} là bắt buộc khi {param}",
+	ValidationKey("required_unless"):      "{field} là bắt buộc trừ khi {param}",
+	ValidationKey("required_with"):        "{field} là bắt buộc khi có {param}",
+	ValidationKey("required_with_all"):    "{field} là bắt buộc khi có tất cả {param}",
+	ValidationKey("required_without"):     "{field} là bắt buộc khi không có {param}",
+	ValidationKey("required_without_all"): "{field} là bắt buộc khi không có {param} nào",
+	ValidationKey("excluded_with"):        "{field} phải để trống khi có {param}",
+	ValidationKey("excluded_with_all"):    "{field} phải để trống khi có tất cả {param}",
+	ValidationKey("excluded_without"):     "{field} phải để trống khi không có {param}",
+	ValidationKey("excluded_without_all"): "{field} phải để trống khi không có {param} nào",
+	ValidationKey("isdefault"):            "{field} phải để trống",
+	ValidationKey("unique"):               "{field} phải chứa các giá trị không trùng lặp",
+
+	// Comparisons
+	ValidationKey("len"):   "{field} phải có độ dài {param} ký tự",
+	ValidationKey("min"):   "{field} phải lớn hơn hoặc bằng {param}",
+	ValidationKey("max"):   "{field} phải nhỏ hơn hoặc bằng {param}",
+	ValidationKey("eq"):    "{field} phải bằng {param}",
+	ValidationKey("ne"):    "{field} không được bằng {param}",
+	ValidationKey("lt"):    "{field} phải nhỏ hơn {param}",
+	ValidationKey("lte"):   "{field} phải nhỏ hơn hoặc bằng {param}",
+	ValidationKey("gt"):    "{field} phải lớn hơn {param}",
+	ValidationKey("gte"):   "{field} phải lớn hơn hoặc bằng {param}",
+	ValidationKey("oneof"): "{field} phải là một trong [{param}]",
+
+	// Cross field
+	ValidationKey("eqfield"):       "{field} phải bằng {param}",
+	ValidationKey("eqcsfield"):     "{field} phải bằng {param}",
+	ValidationKey("nefield"):       "{field} không được bằng {param}",
+	ValidationKey("necsfield"):     "{field} không được bằng {param}",
+	ValidationKey("gtfield"):       "{field} phải lớn hơn {param}",
+	ValidationKey("gtcsfield"):     "{field} phải lớn hơn {param}",
+	ValidationKey("gtefield"):      "{field} phải lớn hơn hoặc bằng {param}",
+	ValidationKey("gtecsfield"):    "{field} phải lớn hơn hoặc bằng {param}",
+	ValidationKey("ltfield"):       "{field} phải nhỏ hơn {param}",
+	ValidationKey("ltcsfield"):     "{field} phải nhỏ hơn {param}",
+	ValidationKey("ltefield"):      "{field} phải nhỏ hơn hoặc bằng {param}",
+	ValidationKey("ltecsfield"):    "{field} phải nhỏ hơn hoặc bằng {param}",
+	ValidationKey("fieldcontains"): "{field} phải chứa giá trị của {param}",
+	ValidationKey("fieldexcludes"): "{field} không được chứa giá trị của {param}",
+
+	// Strings
+	ValidationKey("alpha"):           "{field} chỉ được chứa chữ cái",
+	ValidationKey("alphanum"):        "{field} chỉ được chứa chữ cái và chữ số",
+	ValidationKey("alphaunicode"):    "{field} chỉ được chứa chữ cái unicode",
+	ValidationKey("alphanumunicode"): "{field} chỉ được chứa chữ cái và chữ số unicode",
+	ValidationKey("ascii"):           "{field} chỉ được chứa ký tự ascii",
+	ValidationKey("printascii"):      "{field} chỉ được chứa ký tự ascii in được",
+	ValidationKey("multibyte"):       "{field} phải chứa ký tự multibyte",
+	ValidationKey("lowercase"):       "{field} phải là chữ thường",
+	ValidationKey("uppercase"):       "{field} phải là chữ hoa",
+	ValidationKey("contains"):        "{field} phải chứa chuỗi '{param}'",
+	ValidationKey("containsany"):     "{field} phải chứa ít nhất một trong các ký tự '{param}'",
+	ValidationKey("containsrune"):    "{field} phải chứa ký tự '{param}'",
+	ValidationKey("excludes"):        "{field} không được chứa chuỗi '{param}'",
+	ValidationKey("excludesall"):     "{field} không được chứa bất kỳ ký tự nào trong '{param}'",
+	ValidationKey("excludesrune"):    "{field} không được chứa ký tự '{param}'",
+	ValidationKey("startswith"):      "{field} phải bắt đầu bằng '{param}'",
+	ValidationKey("endswith"):        "{field} phải kết thúc bằng '{param}'",
+	ValidationKey("startsnotwith"):   "{field} không được bắt đầu bằng '{param}'",
+	ValidationKey("endsnotwith"):     "{field} không được kết thúc bằng '{param}'",
+
+	// Formats
+	ValidationKey("boolean"):       "{field} phải là giá trị boolean",
+	ValidationKey("numeric"):       "{field} phải là giá trị số",
+	ValidationKey("number"):        "{field} phải là số",
+	ValidationKey("hexadecimal"):   "{field} phải là hệ thập lục phân",
+	ValidationKey("hexcolor"):      "{field} phải là màu HEX",
+	ValidationKey("rgb"):           "{field} phải là màu RGB",
+	ValidationKey("rgba"):          "{field} phải là màu RGBA",
+	ValidationKey("hsl"):           "{field} phải là màu HSL",
+	ValidationKey("hsla"):          "{field} phải là màu HSLA",
+	ValidationKey("iscolor"):       "{field} phải là màu hợp lệ",
+	ValidationKey("e164"):          "{field} phải là số điện thoại định dạng E.164",
+	ValidationKey("email"):         "Email không đúng định dạng",
+	ValidationKey("url"):           "{field} phải là URL hợp lệ",
+	ValidationKey("uri"):           "{field} phải là URI hợp lệ",
+	ValidationKey("urn_rfc2141"):   "{field} phải là URN RFC 2141 hợp lệ",
+	ValidationKey("file"):          "{field} phải là tệp tồn tại",
+	ValidationKey("dir"):           "{field} phải là thư mục tồn tại",
+	ValidationKey("base64"):        "{field} phải là chuỗi Base64",
+	ValidationKey("base64url"):     "{field} phải là chuỗi Base64 URL",
+	ValidationKey("datauri"):       "{field} phải là Data URI",
+	ValidationKey("html"):          "{field} phải là HTML",
+	ValidationKey("html_encoded"):  "{field} phải được mã hóa HTML",
+	ValidationKey("url_encoded"):   "{field} phải được mã hóa URL",
+	ValidationKey("json"):          "{field} phải là chuỗi JSON hợp lệ",
+	ValidationKey("jwt"):           "{field} phải là JWT hợp lệ",
+	ValidationKey("datetime"):      "{field} không đúng định dạng {param}",
+	ValidationKey("timezone"):      "{field} phải là múi giờ hợp lệ",
+	ValidationKey("isbn"):          "{field} phải là ISBN hợp lệ",
+	ValidationKey("isbn10"):        "{field} phải là ISBN-10 hợp lệ",
+	ValidationKey("isbn13"):        "{field} phải là ISBN-13 hợp lệ",
+	ValidationKey("uuid"):          "{field} phải là UUID hợp lệ",
+	ValidationKey("uuid3"):         "{field} phải là UUID phiên bản 3 hợp lệ",
+	ValidationKey("uuid4"):         "{field} phải là UUID phiên bản 4 hợp lệ",
+	ValidationKey("uuid5"):         "{field} phải là UUID phiên bản 5 hợp lệ",
+	ValidationKey("uuid_rfc4122"):  "{field} phải là UUID RFC 4122 hợp lệ",
+	ValidationKey("uuid3_rfc4122"): "{field} phải là UUID RFC 4122 phiên bản 3 hợp lệ",
+	ValidationKey("uuid4_rfc4122"): "{field} phải là UUID RFC 4122 phiên bản 4 hợp lệ",
+	ValidationKey("uuid5_rfc4122"): "{field} phải là UUID RFC 4122 phiên bản 5 hợp lệ",
+	ValidationKey("ssn"):           "{field} phải là SSN hợp lệ",
+	ValidationKey("bic"):           "{field} phải là mã BIC hợp lệ",
+
+	// Crypto
+	ValidationKey("eth_addr"):        "{field} phải là địa chỉ Ethereum hợp lệ",
+	ValidationKey("btc_addr"):        "{field} phải là địa chỉ Bitcoin hợp lệ",
+	ValidationKey("btc_addr_bech32"): "{field} phải là địa chỉ Bitcoin Bech32 hợp lệ",
+
+	// Geography & locales
+	ValidationKey("latitude"):                      "{field} phải là vĩ độ hợp lệ",
+	ValidationKey("longitude"):                     "{field} phải là kinh độ hợp lệ",
+	ValidationKey("iso3166_1_alpha2"):              "{field} phải là mã quốc gia ISO 3166-1 alpha-2 hợp lệ",
+	ValidationKey("iso3166_1_alpha3"):              "{field} phải là mã quốc gia ISO 3166-1 alpha-3 hợp lệ",
+	ValidationKey("iso3166_1_alpha_numeric"):       "{field} phải là mã số quốc gia ISO 3166-1 hợp lệ",
+	ValidationKey("iso3166_2"):                     "{field} phải là mã vùng ISO 3166-2 hợp lệ",
+	ValidationKey("country_code"):                  "{field} phải là mã quốc gia hợp lệ",
+	ValidationKey("iso4217"):                       "{field} phải là mã tiền tệ hợp lệ",
+	ValidationKey("iso4217_numeric"):               "{field} phải là mã số tiền tệ hợp lệ",
+	ValidationKey("bcp47_language_tag"):            "{field} phải là mã ngôn ngữ BCP 47 hợp lệ",
+	ValidationKey("postcode_iso3166_alpha2"):       "{field} phải là mã bưu chính hợp lệ của {param}",
+	ValidationKey("postcode_iso3166_alpha2_field"): "{field} phải là mã bưu chính hợp lệ của quốc gia trong {param}",
+
+	// Network
+	ValidationKey("ip"):               "{field} phải là địa chỉ IP hợp lệ",
+	ValidationKey("ipv4"):             "{field} phải là địa chỉ IPv4 hợp lệ",
+	ValidationKey("ipv6"):             "{field} phải là địa chỉ IPv6 hợp lệ",
+	ValidationKey("cidr"):             "{field} phải là ký hiệu CIDR hợp lệ",
+	ValidationKey("cidrv4"):           "{field} phải là ký hiệu CIDR hợp lệ của địa chỉ IPv4",
+	ValidationKey("cidrv6"):           "{field} phải là ký hiệu CIDR hợp lệ của địa chỉ IPv6",
+	ValidationKey("tcp_addr"):         "{field} phải là địa chỉ TCP hợp lệ",
+	ValidationKey("tcp4_addr"):        "{field} phải là địa chỉ TCP IPv4 hợp lệ",
+	ValidationKey("tcp6_addr"):        "{field} phải là địa chỉ TCP IPv6 hợp lệ",
+	ValidationKey("udp_addr"):         "{field} phải là địa chỉ UDP hợp lệ",
+	ValidationKey("udp4_addr"):        "{field} phải là địa chỉ UDP IPv4 hợp lệ",
+	ValidationKey("udp6_addr"):        "{field} phải là địa chỉ UDP IPv6 hợp lệ",
+	ValidationKey("ip_addr"):          "{field} phải là địa chỉ IP phân giải được",
+	ValidationKey("ip4_addr"):         "{field} phải là địa chỉ IPv4 phân giải được",
+	ValidationKey("ip6_addr"):         "{field} phải là địa chỉ IPv6 phân giải được",
+	ValidationKey("unix_addr"):        "{field} phải là địa chỉ UNIX phân giải được",
+	ValidationKey("mac"):              "{field} phải là địa chỉ MAC hợp lệ",
+	ValidationKey("hostname"):         "{field} phải là hostname hợp lệ",
+	ValidationKey("hostname_rfc1123"): "{field} phải là hostname RFC 1123 hợp lệ",
+	ValidationKey("hostname_port"):    "{field} phải là host:port hợp lệ",
+	ValidationKey("fqdn"):             "{field} phải là FQDN hợp lệ",
+}
@@ -4,13 +4,16 @@ import _ "github.com/rayyone/go-core/helpers/array"
 import _ "github.com/rayyone/go-core/helpers/dataparser"
 import _ "github.com/rayyone/go-core/helpers/file"
 import _ "github.com/rayyone/go-core/helpers/httpclient"
+import _ "github.com/rayyone/go-core/helpers/i18n"
 import _ "github.com/rayyone/go-core/helpers/image"
 import _ "github.com/rayyone/go-core/helpers/istype"
+import _ "github.com/rayyone/go-core/helpers/jwt"
 import _ "github.com/rayyone/go-core/helpers/log"
 import _ "github.com/rayyone/go-core/helpers/maps"
 import _ "github.com/rayyone/go-core/helpers/method"
 import _ "github.com/rayyone/go-core/helpers/net"
 import _ "github.com/rayyone/go-core/helpers/pagination"
+import _ "github.com/rayyone/go-core/helpers/requestid"
 import _ "github.com/rayyone/go-core/helpers/response"
 import _ "github.com/rayyone/go-core/helpers/retry"
 import _ "github.com/rayyone/go-core/helpers/str"
@@ -0,0 +1,238 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rayyone/go-core/helpers/httpclient"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// JWK is a JSON Web Key (RFC 7517). Only RSA ("RSA") and symmetric ("oct") keys are supported
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+	// N and E are the RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// K is the symmetric key
+	K string `json:"k,omitempty"`
+}
+
+// Key returns the verification key: an *rsa.PublicKey or a []byte secret
+func (k JWK) Key() (interface{}, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, ryerr.Wrapf(err, "Invalid RSA modulus of key '%s'", k.KeyID)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, ryerr.Wrapf(err, "Invalid RSA exponent of key '%s'", k.KeyID)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, ryerr.Wrapf(err, "Invalid secret of key '%s'", k.KeyID)
+		}
+		return secret, nil
+	}
+	return nil, ryerr.Wrapf(ErrUnsupportedKey, "Key '%s' of type '%s'", k.KeyID, k.KeyType)
+}
+
+func (k JWK) matches(header Header) bool {
+	if k.Use != "" && k.Use != "sig" {
+		return false
+	}
+	if k.Algorithm != "" && k.Algorithm != header.Algorithm {
+		return false
+	}
+	switch header.Algorithm {
+	case HS256:
+		return k.KeyType == "oct"
+	case RS256:
+		return k.KeyType == "RSA"
+	}
+	return false
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWKSOptions struct {
+	// TTL is the time the keys are cached before being loaded again. Default to 1 hour
+	TTL time.Duration
+	// MinRefreshInterval limits the reloads triggered by tokens signed with an unknown key. Default to 1 minute
+	MinRefreshInterval time.Duration
+}
+
+func DefaultJWKSOptions() JWKSOptions {
+	return JWKSOptions{
+		TTL:                time.Hour,
+		MinRefreshInterval: time.Minute,
+	}
+}
+
+// JWKSKeySet is a KeySet loaded from a JWKS document. The keys are cached and reloaded when a token is signed
+// with an unknown key ID, so the signing keys can be rotated without restart
+type JWKSKeySet struct {
+	load     func(ctx context.Context) (JWKS, error)
+	options  JWKSOptions
+	lock     sync.Mutex
+	keys     map[string]interface{}
+	jwks     JWKS
+	loadedAt time.Time
+	loadErr  error
+	// loading is closed when the load in progress is done
+	loading chan struct{}
+}
+
+func newJWKSKeySet(load func(ctx context.Context) (JWKS, error), options JWKSOptions) *JWKSKeySet {
+	if options.TTL <= 0 {
+		options.TTL = DefaultJWKSOptions().TTL
+	}
+	if options.MinRefreshInterval <= 0 {
+		options.MinRefreshInterval = DefaultJWKSOptions().MinRefreshInterval
+	}
+	return &JWKSKeySet{load: load, options: options}
+}
+
+// NewJWKSFromFile returns a KeySet reading the JWKS file. Replace the file to rotate the keys
+func NewJWKSFromFile(path string, options JWKSOptions) *JWKSKeySet {
+	return newJWKSKeySet(func(ctx context.Context) (JWKS, error) {
+		var jwks JWKS
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return jwks, ryerr.Wrapf(err, "Cannot read JWKS file '%s'", path)
+		}
+		if err := json.Unmarshal(bs, &jwks); err != nil {
+			return jwks, ryerr.Wrapf(err, "Cannot parse JWKS file '%s'", path)
+		}
+		return jwks, nil
+	}, options)
+}
+
+// NewJWKSFromURL returns a KeySet fetching the JWKS document at the URL, e.g. https://issuer/.well-known/jwks.json
+func NewJWKSFromURL(url string, options JWKSOptions) *JWKSKeySet {
+	return newJWKSKeySet(func(ctx context.Context) (JWKS, error) {
+		var jwks JWKS
+		err := httpclient.Get(url, nil, &jwks, httpclient.Context(ctx), httpclient.DontReportOnRequestError())
+		return jwks, err
+	}, options)
+}
+
+// Key returns the key matching the key ID and algorithm of the token. A token without key ID gets the only key
+// matching its algorithm
+func (s *JWKSKeySet) Key(ctx context.Context, header Header) (interface{}, error) {
+	s.lock.Lock()
+	if s.keys == nil && s.loadErr != nil && time.Since(s.loadedAt) < s.options.MinRefreshInterval {
+		err := s.loadErr
+		s.lock.Unlock()
+		return nil, err
+	}
+	expired := s.keys == nil || time.Since(s.loadedAt) >= s.options.TTL
+	if !expired {
+		if key, ok := s.find(header); ok {
+			s.lock.Unlock()
+			return key, nil
+		}
+	}
+	// The keys may have been rotated
+	refresh := expired || time.Since(s.loadedAt) >= s.options.MinRefreshInterval
+	s.lock.Unlock()
+
+	if refresh {
+		// If they cannot be reloaded, the cached ones are kept
+		if err := s.reload(ctx); err != nil && !s.hasKeys() {
+			return nil, err
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if key, ok := s.find(header); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: '%s'", ErrUnknownKey, header.KeyID)
+}
+
+func (s *JWKSKeySet) hasKeys() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.keys != nil
+}
+
+// reload loads the keys without holding the lock, so the verifications with the cached keys aren't blocked.
+// Concurrent reloads wait for the one in progress. The keys that cannot be parsed are skipped
+func (s *JWKSKeySet) reload(ctx context.Context) error {
+	s.lock.Lock()
+	if loading := s.loading; loading != nil {
+		s.lock.Unlock()
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.loadErr
+	}
+	loading := make(chan struct{})
+	s.loading = loading
+	s.lock.Unlock()
+
+	// The keys are shared: a cancelled request doesn't cancel their loading
+	jwks, err := s.load(context.WithoutCancel(ctx))
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.loading = nil
+	close(loading)
+	// Don't hammer the source when it fails
+	s.loadedAt = time.Now()
+	s.loadErr = err
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if key, err := jwk.Key(); err == nil {
+			keys[jwk.KeyID] = key
+		}
+	}
+	s.keys = keys
+	s.jwks = jwks
+	return nil
+}
+
+func (s *JWKSKeySet) find(header Header) (interface{}, bool) {
+	var found interface{}
+	count := 0
+	for _, jwk := range s.jwks.Keys {
+		key, ok := s.keys[jwk.KeyID]
+		if !ok || !jwk.matches(header) {
+			continue
+		}
+		if header.KeyID != "" && jwk.KeyID == header.KeyID {
+			return key, true
+		}
+		found = key
+		count++
+	}
+	if header.KeyID == "" && count == 1 {
+		return found, true
+	}
+	return nil, false
+}
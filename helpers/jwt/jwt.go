@@ -0,0 +1,298 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rayyone/go-core/ryerr"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// Verification failures. The errors returned by Verifier.Verify are ryerr.Unauthorized errors wrapping one of them
+var (
+	ErrMalformed        = errors.New("malformed token")
+	ErrAlgorithm        = errors.New("unsupported signing algorithm")
+	ErrUnknownKey       = errors.New("unknown signing key")
+	ErrSignature        = errors.New("invalid signature")
+	ErrExpired          = errors.New("token has expired")
+	ErrNotYetValid      = errors.New("token is not valid yet")
+	ErrInvalidIssuer    = errors.New("invalid issuer")
+	ErrInvalidAudience  = errors.New("invalid audience")
+	ErrMissingExpiresAt = errors.New("token has no expiration time")
+	ErrUnsupportedKey   = errors.New("unsupported key type")
+)
+
+// Header is the JOSE header of a token
+type Header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// Claims are the claims of a verified token
+type Claims map[string]interface{}
+
+func (c Claims) String(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+// Time returns a NumericDate claim (seconds since the epoch, possibly fractional). False if it is absent or malformed
+func (c Claims) Time(key string) (time.Time, bool) {
+	t, present, err := c.numericDate(key)
+	return t, present && err == nil
+}
+
+// numericDate returns a NumericDate claim, whether it is present, and an error if it is present but malformed
+func (c Claims) numericDate(key string) (time.Time, bool, error) {
+	value, ok := c[key]
+	if !ok || value == nil {
+		return time.Time{}, false, nil
+	}
+	var seconds float64
+	switch value := value.(type) {
+	case float64:
+		seconds = value
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return time.Time{}, true, err
+		}
+		seconds = f
+	default:
+		return time.Time{}, true, fmt.Errorf("claim '%s' is not a number", key)
+	}
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return time.Time{}, true, fmt.Errorf("claim '%s' is not a finite number", key)
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*float64(time.Second))), true, nil
+}
+
+// Subject returns the "sub" claim
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+func (c Claims) Issuer() string {
+	return c.String("iss")
+}
+
+// Audience returns the "aud" claim, a string or an array of strings
+func (c Claims) Audience() []string {
+	return c.Strings("aud")
+}
+
+// Scopes returns the space separated "scope" claim (RFC 8693), or the "scp" / "scopes" array claims
+func (c Claims) Scopes() []string {
+	if scope := c.String("scope"); scope != "" {
+		return strings.Fields(scope)
+	}
+	if scopes := c.Strings("scp"); len(scopes) > 0 {
+		return scopes
+	}
+	return c.Strings("scopes")
+}
+
+// Strings returns a claim holding a string or an array of strings
+func (c Claims) Strings(key string) []string {
+	switch value := c[key].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		res := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+// KeySet gives the verification key of a token: a []byte secret for HS256, an *rsa.PublicKey for RS256
+type KeySet interface {
+	Key(ctx context.Context, header Header) (interface{}, error)
+}
+
+type VerifierOptions struct {
+	// Algorithms are the accepted signing algorithms. Default to HS256 and RS256
+	Algorithms []string
+	// Secret is the HS256 key. Ignored when KeySet is set
+	Secret []byte
+	// KeySet gives the keys by key ID, see NewJWKSFromFile and NewJWKSFromURL
+	KeySet KeySet
+	// Issuer and Audience are checked when set
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew when checking the exp and nbf claims
+	Leeway time.Duration
+	// AllowMissingExpiresAt accepts the tokens without exp claim, which never expire. They are rejected by default
+	AllowMissingExpiresAt bool
+}
+
+// Verifier verifies signed tokens (JWS compact serialization)
+type Verifier struct {
+	options    VerifierOptions
+	algorithms map[string]bool
+}
+
+func NewVerifier(options VerifierOptions) *Verifier {
+	if len(options.Algorithms) == 0 {
+		options.Algorithms = []string{HS256, RS256}
+	}
+	algorithms := make(map[string]bool, len(options.Algorithms))
+	for _, alg := range options.Algorithms {
+		algorithms[alg] = true
+	}
+	return &Verifier{options: options, algorithms: algorithms}
+}
+
+// unauthorized returns an Unauthorized error responding msg, the cause being kept in the chain
+func unauthorized(cause error, msg string) error {
+	return ryerr.Msg(ryerr.Unauthorized.Wrap(cause, msg), msg)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	bs, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(bs)))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// Verify checks the signature of the token and its registered claims, and returns its claims
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, unauthorized(ErrMalformed, "Invalid token")
+	}
+	var header Header
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, unauthorized(ErrMalformed, "Invalid token header")
+	}
+	if !v.algorithms[header.Algorithm] {
+		return nil, unauthorized(ErrAlgorithm, "Token signing algorithm is not accepted")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, unauthorized(ErrMalformed, "Invalid token signature")
+	}
+
+	key, err := v.key(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Algorithm, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, unauthorized(ErrMalformed, "Invalid token claims")
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Verifier) key(ctx context.Context, header Header) (interface{}, error) {
+	if v.options.KeySet != nil {
+		key, err := v.options.KeySet.Key(ctx, header)
+		if err != nil {
+			if errors.Is(err, ErrUnknownKey) {
+				return nil, unauthorized(err, "Token signing key is unknown")
+			}
+			// The key set could not be loaded: this is our failure, not the client's
+			return nil, ryerr.Wrap(err, "Cannot load the token signing keys")
+		}
+		return key, nil
+	}
+	if header.Algorithm == HS256 && len(v.options.Secret) > 0 {
+		return v.options.Secret, nil
+	}
+	return nil, unauthorized(ErrUnknownKey, "Token signing key is unknown")
+}
+
+func verifySignature(alg string, key interface{}, signingInput string, signature []byte) error {
+	switch alg {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return unauthorized(ErrUnknownKey, "Token signing key does not match the algorithm")
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return unauthorized(ErrSignature, "Invalid token signature")
+		}
+	case RS256:
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return unauthorized(ErrUnknownKey, "Token signing key does not match the algorithm")
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return unauthorized(ErrSignature, "Invalid token signature")
+		}
+	default:
+		return unauthorized(ErrAlgorithm, "Token signing algorithm is not accepted")
+	}
+	return nil
+}
+
+func (v *Verifier) validateClaims(claims Claims) error {
+	now := time.Now()
+	exp, ok, err := claims.numericDate("exp")
+	if err != nil {
+		return unauthorized(fmt.Errorf("%w: %w", ErrMalformed, err), "Invalid token expiration time")
+	}
+	if ok {
+		if now.After(exp.Add(v.options.Leeway)) {
+			return unauthorized(ErrExpired, "Token has expired")
+		}
+	} else if !v.options.AllowMissingExpiresAt {
+		return unauthorized(ErrMissingExpiresAt, "Token has no expiration time")
+	}
+	nbf, ok, err := claims.numericDate("nbf")
+	if err != nil {
+		return unauthorized(fmt.Errorf("%w: %w", ErrMalformed, err), "Invalid token not before time")
+	}
+	if ok && now.Add(v.options.Leeway).Before(nbf) {
+		return unauthorized(ErrNotYetValid, "Token is not valid yet")
+	}
+	if v.options.Issuer != "" && claims.Issuer() != v.options.Issuer {
+		return unauthorized(ErrInvalidIssuer, "Invalid token issuer")
+	}
+	if v.options.Audience != "" {
+		valid := false
+		for _, aud := range claims.Audience() {
+			if aud == v.options.Audience {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return unauthorized(ErrInvalidAudience, "Invalid token audience")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package jwt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("secret")
+
+func signHS256(t *testing.T, claims string) string {
+	t.Helper()
+	input := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyNumericDates(t *testing.T) {
+	verifier := NewVerifier(VerifierOptions{Secret: testSecret})
+	tests := []struct {
+		name   string
+		claims string
+		err    error
+	}{
+		{"fractional exp in the future", `{"sub":"1","exp":4102444800.5}`, nil},
+		{"fractional exp in the past", `{"sub":"1","exp":946684800.5}`, ErrExpired},
+		{"string exp", `{"sub":"1","exp":"4102444800"}`, ErrMalformed},
+		{"string nbf", `{"sub":"1","exp":4102444800,"nbf":"0"}`, ErrMalformed},
+		{"no exp", `{"sub":"1"}`, ErrMissingExpiresAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), signHS256(t, tt.claims))
+			if tt.err == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, expected %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestVerifyAllowMissingExpiresAt(t *testing.T) {
+	verifier := NewVerifier(VerifierOptions{Secret: testSecret, AllowMissingExpiresAt: true})
+	if _, err := verifier.Verify(context.Background(), signHS256(t, `{"sub":"1"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestJWKSReloadIsShared(t *testing.T) {
+	var loads int32
+	release := make(chan struct{})
+	keySet := newJWKSKeySet(func(ctx context.Context) (JWKS, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return JWKS{Keys: []JWK{{KeyType: "oct", KeyID: "k1", K: base64.RawURLEncoding.EncodeToString(testSecret)}}}, nil
+	}, JWKSOptions{TTL: time.Hour, MinRefreshInterval: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := keySet.Key(context.Background(), Header{Algorithm: HS256, KeyID: "k1"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if loads != 1 {
+		t.Errorf("loads = %d, expected 1", loads)
+	}
+}
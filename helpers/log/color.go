@@ -0,0 +1,134 @@
+package loghelper
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	colorReset   = "\033[0m"
+	colorRed     = "\033[36;31m"
+	colorYellow  = "\033[33m"
+	colorMagenta = "\033[35m"
+	colorGray    = "\033[90m"
+)
+
+// ColorHandler writes human friendly records, colored by level, e.g.
+// "2006-01-02 15:04:05 WARN Retrying attempt=1 max_retry=3". Meant for terminals, see FormatAuto
+type ColorHandler struct {
+	options slog.HandlerOptions
+	// prefix holds the attributes added with WithAttrs, already formatted
+	prefix string
+	group  string
+	lock   *sync.Mutex
+	output io.Writer
+}
+
+func NewColorHandler(output io.Writer, options *slog.HandlerOptions) *ColorHandler {
+	h := &ColorHandler{lock: &sync.Mutex{}, output: output}
+	if options != nil {
+		h.options = *options
+	}
+	return h
+}
+
+func (h *ColorHandler) Enabled(_ context.Context, l slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.options.Level != nil {
+		minLevel = h.options.Level.Level()
+	}
+	return l >= minLevel
+}
+
+func levelColor(l slog.Level) string {
+	switch {
+	case l >= slog.LevelError:
+		return colorRed
+	case l >= slog.LevelWarn:
+		return colorYellow
+	case l >= slog.LevelInfo:
+		return ""
+	default:
+		return colorMagenta
+	}
+}
+
+func (h *ColorHandler) Handle(_ context.Context, record slog.Record) error {
+	var buf bytes.Buffer
+	if !record.Time.IsZero() {
+		buf.WriteString(colorGray + record.Time.Format("2006-01-02 15:04:05") + colorReset + " ")
+	}
+	color := levelColor(record.Level)
+	buf.WriteString(fmt.Sprintf("%s%-5s %s%s", color, record.Level.String(), record.Message, colorReset))
+	if h.options.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		buf.WriteString(fmt.Sprintf(" %ssource=%s:%d%s", colorGray, filepath.Base(frame.File), frame.Line, colorReset))
+	}
+	buf.WriteString(h.prefix)
+	record.Attrs(func(attr slog.Attr) bool {
+		appendAttr(&buf, h.group, attr)
+		return true
+	})
+	buf.WriteByte('\n')
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	_, err := h.output.Write(buf.Bytes())
+	return err
+}
+
+func (h *ColorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var buf bytes.Buffer
+	for _, attr := range attrs {
+		appendAttr(&buf, h.group, attr)
+	}
+	clone := *h
+	clone.prefix += buf.String()
+	return &clone
+}
+
+func (h *ColorHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.group = joinKey(h.group, name)
+	return &clone
+}
+
+func joinKey(group string, key string) string {
+	if group == "" {
+		return key
+	}
+	return group + "." + key
+}
+
+// appendAttr writes " key=value", the keys of nested groups are joined with dots
+func appendAttr(buf *bytes.Buffer, group string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			group = joinKey(group, attr.Key)
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			appendAttr(buf, group, groupAttr)
+		}
+		return
+	}
+	value := attr.Value.String()
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	buf.WriteString(" " + colorGray + joinKey(group, attr.Key) + "=" + colorReset + value)
+}
@@ -1,31 +1,63 @@
 package loghelper
 
 import (
-	"log"
-
-	strhelper "github.com/rayyone/go-core/helpers/str"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
 )
 
+// logAt logs with the root logger, the source being the caller of the Print function
+func logAt(l slog.Level, msg string) {
+	ctx := context.Background()
+	if !root.Enabled(ctx, l) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	record := slog.NewRecord(time.Now(), l, msg, pcs[0])
+	_ = root.Handler().Handle(ctx, record)
+}
+
+// PrintRed logs at error level.
+//
+// Deprecated: use Default().Error or a Named logger.
 func PrintRed(str string) {
-	log.Println(strhelper.Red(str))
+	logAt(LevelError, str)
 }
 
+// PrintRedf logs at error level.
+//
+// Deprecated: use Default().Error or a Named logger.
 func PrintRedf(format string, args ...interface{}) {
-	log.Println(strhelper.Redf(format, args...))
+	logAt(LevelError, fmt.Sprintf(format, args...))
 }
 
+// PrintYellow logs at info level.
+//
+// Deprecated: use Default().Info or a Named logger.
 func PrintYellow(str string) {
-	log.Println(strhelper.Yellow(str))
+	logAt(LevelInfo, str)
 }
 
+// PrintYellowf logs at info level.
+//
+// Deprecated: use Default().Info or a Named logger.
 func PrintYellowf(format string, args ...interface{}) {
-	log.Println(strhelper.Yellowf(format, args...))
+	logAt(LevelInfo, fmt.Sprintf(format, args...))
 }
 
+// PrintMagenta logs at info level.
+//
+// Deprecated: use Default().Info or a Named logger.
 func PrintMagenta(str string) {
-	log.Println(strhelper.Magenta(str))
+	logAt(LevelInfo, str)
 }
 
+// PrintMagentaf logs at info level.
+//
+// Deprecated: use Default().Info or a Named logger.
 func PrintMagentaf(format string, args ...interface{}) {
-	log.Println(strhelper.Magentaf(format, args...))
+	logAt(LevelInfo, fmt.Sprintf(format, args...))
 }
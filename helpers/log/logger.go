@@ -0,0 +1,215 @@
+package loghelper
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mattn/go-isatty"
+	"github.com/rayyone/go-core/helpers/requestid"
+)
+
+const (
+	LevelDebug = slog.LevelDebug
+	LevelInfo  = slog.LevelInfo
+	LevelWarn  = slog.LevelWarn
+	LevelError = slog.LevelError
+)
+
+// Output formats
+const (
+	// FormatAuto uses FormatColor when the output is a terminal, FormatText otherwise
+	FormatAuto  = "auto"
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatColor = "color"
+)
+
+const (
+	// LoggerKey is the attribute naming the package a child logger belongs to, see Named
+	LoggerKey = "logger"
+	// RequestIDKey is the attribute holding the request ID, see FromContext
+	RequestIDKey = "request_id"
+)
+
+type Config struct {
+	Level slog.Level
+	// Format is one of FormatAuto (default), FormatText, FormatJSON or FormatColor
+	Format string
+	// Output default to os.Stderr
+	Output io.Writer
+	// AddSource adds the file and line of the log call
+	AddSource bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Level:  LevelInfo,
+		Format: FormatAuto,
+		Output: os.Stderr,
+	}
+}
+
+var (
+	level       = new(slog.LevelVar)
+	rootHandler atomic.Pointer[slog.Handler]
+	root        = slog.New(&swappableHandler{})
+)
+
+func init() {
+	Setup(DefaultConfig())
+}
+
+// Setup sets the level, format and output of every logger of the package, including the child loggers already created
+func Setup(config Config) {
+	if config.Output == nil {
+		config.Output = os.Stderr
+	}
+	level.Set(config.Level)
+	options := &slog.HandlerOptions{Level: level, AddSource: config.AddSource}
+
+	var handler slog.Handler
+	switch strings.ToLower(config.Format) {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(config.Output, options)
+	case FormatText:
+		handler = slog.NewTextHandler(config.Output, options)
+	case FormatColor:
+		handler = NewColorHandler(config.Output, options)
+	default:
+		if isTerminal(config.Output) {
+			handler = NewColorHandler(config.Output, options)
+		} else {
+			handler = slog.NewTextHandler(config.Output, options)
+		}
+	}
+	SetHandler(handler)
+}
+
+// SetHandler replaces the handler of every logger of the package, e.g. to send the logs to a custom handler.
+// Each call stores a new pointer, which tells the child loggers to derive their handler again
+func SetHandler(handler slog.Handler) {
+	rootHandler.Store(&handler)
+}
+
+// SetLevel sets the minimum level logged
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// ParseLevel parses a level name (debug, info, warn, error). Unknown names give LevelInfo
+func ParseLevel(name string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return LevelInfo
+	}
+	return l
+}
+
+// Default returns the root logger
+func Default() *slog.Logger {
+	return root
+}
+
+// Named returns a child logger for a package. Its records carry the logger=name attribute
+func Named(name string) *slog.Logger {
+	return root.With(LoggerKey, name)
+}
+
+type loggerCtxKey struct{}
+
+// NewContext returns a context carrying the logger
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// FromContext returns the logger carried by the context, or the root logger.
+// The request ID held in the context (see requestid.FromContext) is added to the records
+func FromContext(ctx context.Context) *slog.Logger {
+	logger := root
+	if ctx == nil {
+		return logger
+	}
+	if ctxLogger, ok := ctx.Value(loggerCtxKey{}).(*slog.Logger); ok {
+		return ctxLogger
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		return logger.With(RequestIDKey, id)
+	}
+	return logger
+}
+
+// WithContext returns the logger with the request ID held in the context, see FromContext
+func WithContext(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return logger.With(RequestIDKey, id)
+	}
+	return logger
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// swappableHandler forwards the records to the handler set with SetHandler at the time they are logged,
+// so the loggers created before Setup follow it
+type swappableHandler struct {
+	// ops replay the WithAttrs and WithGroup calls on the current handler
+	ops []func(slog.Handler) slog.Handler
+	// derived caches the ops replayed on the root handler, until SetHandler stores a new one
+	derived atomic.Pointer[derivedHandler]
+}
+
+type derivedHandler struct {
+	root    *slog.Handler
+	handler slog.Handler
+}
+
+func (h *swappableHandler) current() slog.Handler {
+	root := rootHandler.Load()
+	if len(h.ops) == 0 {
+		return *root
+	}
+	if derived := h.derived.Load(); derived != nil && derived.root == root {
+		return derived.handler
+	}
+	handler := *root
+	for _, op := range h.ops {
+		handler = op(handler)
+	}
+	h.derived.Store(&derivedHandler{root: root, handler: handler})
+	return handler
+}
+
+func (h *swappableHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return (*rootHandler.Load()).Enabled(ctx, l)
+}
+
+func (h *swappableHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.current().Handle(ctx, record)
+}
+
+func (h *swappableHandler) with(op func(slog.Handler) slog.Handler) *swappableHandler {
+	ops := make([]func(slog.Handler) slog.Handler, len(h.ops), len(h.ops)+1)
+	copy(ops, h.ops)
+	return &swappableHandler{ops: append(ops, op)}
+}
+
+func (h *swappableHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+func (h *swappableHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
@@ -0,0 +1,40 @@
+package loghelper
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+// countingHandler counts the handlers derived from it
+type countingHandler struct {
+	derived *int
+}
+
+func (h countingHandler) Enabled(context.Context, slog.Level) bool  { return true }
+func (h countingHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h countingHandler) WithGroup(string) slog.Handler             { *h.derived++; return h }
+func (h countingHandler) WithAttrs([]slog.Attr) slog.Handler {
+	*h.derived++
+	return h
+}
+
+func TestNamedLoggerDerivesItsHandlerOnce(t *testing.T) {
+	previous := *rootHandler.Load()
+	defer SetHandler(previous)
+
+	derived := 0
+	SetHandler(countingHandler{derived: &derived})
+	logger := Named("test")
+	logger.Info("first")
+	logger.Info("second")
+	if derived != 1 {
+		t.Errorf("handler derived %d times for 2 records, expected 1", derived)
+	}
+
+	SetHandler(countingHandler{derived: &derived})
+	logger.Info("third")
+	if derived != 2 {
+		t.Errorf("handler derived %d times after SetHandler, expected 2", derived)
+	}
+}
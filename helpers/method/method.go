@@ -119,6 +119,7 @@ func GuessTime(t interface{}) (*time.Time, error) {
 func ValidateStruct(param interface{}) error {
 	validate := validator.New()
 	validate.SetTagName("binding")
+	RegisterJSONFieldNames(validate)
 	if err := validate.Struct(param); err != nil {
 		return err
 	}
@@ -139,6 +140,10 @@ func Optional(x interface{}, def interface{}) interface{} {
 	return x
 }
 
+// MultipartMemory is the size of the multipart form parts kept in memory by GetBodyParams, the rest is stored in temporary files.
+// Limit the size of the body with middleware.BodyLimit
+var MultipartMemory int64 = 32 << 20
+
 // Get body params from request (gin context) & allow multiple body reading
 func GetBodyParams(c *gin.Context) (interface{}, error) {
 	if c.Request.Method == http.MethodGet || c.Request.Body == http.NoBody {
@@ -157,8 +162,10 @@ func GetBodyParams(c *gin.Context) (interface{}, error) {
 			return bodyParams, nil
 		}
 	} else if b == binding.FormMultipart {
-		err = c.Request.ParseMultipartForm(0) // multipart/form-data
-		assignMultipartForm(objectBodyParams, c.Request.MultipartForm)
+		err = c.Request.ParseMultipartForm(MultipartMemory) // multipart/form-data
+		if err == nil {
+			assignMultipartForm(objectBodyParams, c.Request.MultipartForm)
+		}
 	} else if b == binding.Form {
 		err = c.Request.ParseForm() // application/x-www-form-urlencoded
 		for key, value := range c.Request.PostForm {
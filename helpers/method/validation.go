@@ -0,0 +1,66 @@
+package method
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func isAllUpper(s string) bool {
+	for _, v := range s {
+		if !unicode.IsUpper(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func lowerCaseFirst(s string) string {
+	if len(s) < 2 {
+		return strings.ToLower(s)
+	}
+	for i, v := range s {
+		return string(unicode.ToLower(v)) + s[i+1:]
+	}
+	return ""
+}
+
+func tagName(fld reflect.StructField, key string) string {
+	name := strings.SplitN(fld.Tag.Get(key), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// JSONFieldName returns the name of a struct field in validation errors: its json tag name, then its form tag name.
+// Untagged fields fall back to the Go field name with a lower case first letter ("ID" becomes "id")
+func JSONFieldName(fld reflect.StructField) string {
+	if name := tagName(fld, "json"); name != "" {
+		return name
+	}
+	if name := tagName(fld, "form"); name != "" {
+		return name
+	}
+	if isAllUpper(fld.Name) {
+		return strings.ToLower(fld.Name)
+	}
+	return lowerCaseFirst(fld.Name)
+}
+
+// RegisterJSONFieldNames makes the validator name fields with JSONFieldName, see FieldPath
+func RegisterJSONFieldNames(validate *validator.Validate) {
+	validate.RegisterTagNameFunc(JSONFieldName)
+}
+
+// FieldPath returns the full path of the field in error without the top struct name, e.g. "items[2].price".
+// The validator must be set up with RegisterJSONFieldNames to get the json names
+func FieldPath(e validator.FieldError) string {
+	namespace := e.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
@@ -0,0 +1,62 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header is the HTTP header carrying the request ID, in incoming requests, responses and outgoing calls
+	Header = "X-Request-ID"
+	// GinKey is the gin context key of the request ID
+	GinKey = "request_id"
+
+	maxLength = 128
+)
+
+type ctxKey struct{}
+
+// New generates a request ID
+func New() string {
+	return uuid.NewString()
+}
+
+// IsValid Check if a request ID received from a client can be trusted in logs and headers:
+// not empty, at most 128 characters, only letters, digits and - _ . : characters
+func IsValid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a context holding the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID held in the context (or in the gin context), an empty string if there is none
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(ctxKey{}).(string); ok {
+		return id
+	}
+	if id, ok := ctx.Value(GinKey).(string); ok {
+		return id
+	}
+	return ""
+}
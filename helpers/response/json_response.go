@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/rayyone/go-core/helpers/i18n"
 	"github.com/rayyone/go-core/ryerr"
 	"github.com/rayyone/go-core/helpers/pagination"
 	"github.com/gin-gonic/gin"
@@ -26,9 +27,10 @@ type PaginatorResponse struct {
 
 type ErrorResponse struct {
 	StandardResponse
-	ErrorCode string            `json:"error_code"`
-	Errors    []string          `json:"errors"`
-	Fields    map[string]string `json:"fields"`
+	ErrorCode   string             `json:"error_code"`
+	Errors      []string           `json:"errors"`
+	Fields      map[string]string  `json:"fields"`
+	FieldErrors []ryerr.FieldError `json:"field_errors"`
 }
 
 func BuildStandardResponse(status string, message string) StandardResponse {
@@ -52,14 +54,15 @@ func BuildErrorResponse(err error, errorCode string, message string) ErrorRespon
 	var errMsgs []string
 
 	response.StandardResponse = BuildStandardResponse("error", message)
-	contexts := ryerr.GetErrorContexts(err)
-	for _, v := range contexts {
-		errMsgs = append(errMsgs, v)
+	fieldErrors := ryerr.GetFieldErrors(err)
+	for _, fieldError := range fieldErrors {
+		errMsgs = append(errMsgs, fieldError.Message)
 	}
 	if response.Errors = errMsgs; errMsgs == nil {
 		response.Errors = []string{message}
 	}
-	response.Fields = contexts
+	response.Fields = ryerr.GetErrorContexts(err)
+	response.FieldErrors = fieldErrors
 	response.ErrorCode = errorCode
 
 	return response
@@ -88,17 +91,43 @@ func RespondError(c *gin.Context, err error) {
 	switch errType {
 	case ryerr.Unauthorized:
 		defaultMessage = "Unauthorized."
+	case ryerr.Forbidden:
+		defaultMessage = "Forbidden."
 	case ryerr.NotFound:
 		defaultMessage = "Resource not found."
+	case ryerr.Conflict:
+		defaultMessage = "Conflict."
+	case ryerr.RequestEntityTooLarge:
+		defaultMessage = "Request entity too large."
 	case ryerr.UnprocessableEntity:
 		defaultMessage = "Unprocessable entity error."
 	case ryerr.BadRequest:
 		defaultMessage = "Bad request."
+	case ryerr.ServiceUnavailable:
+		defaultMessage = "Service unavailable."
+	case ryerr.GatewayTimeout:
+		defaultMessage = "Request timed out."
 	default:
 		defaultMessage = "Internal server error."
 	}
 
 	message := err.Error()
+	var statusCode int
+	if code, ok := ryerr.GetCode(err); ok {
+		// Application error code registered with ryerr.RegisterCode
+		errorCode = code.Code
+		statusCode = code.Status
+		if code.Message != "" {
+			defaultMessage = code.Message
+		}
+		// Translate the registered message of the code, unless the error carries its own message
+		if message == "" || message == code.Message {
+			if localized := i18n.T(i18n.GetLocale(c), i18n.ErrorKey(code.Code), nil); localized != "" {
+				message = localized
+			}
+		}
+	}
+
 	if message == "" {
 		message = defaultMessage
 	}
@@ -106,7 +135,9 @@ func RespondError(c *gin.Context, err error) {
 		// Get error code from err type value
 		errorCode = strconv.Itoa(int(errType))
 	}
-	statusCode := getStatusCode(errorCode)
+	if statusCode == 0 {
+		statusCode = getStatusCode(errorCode)
+	}
 	c.JSON(statusCode, BuildErrorResponse(err, errorCode, message))
 }
 
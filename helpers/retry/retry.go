@@ -1,16 +1,31 @@
 package retry
 
 import (
+	"context"
+	"math"
+	"math/rand"
 	"time"
 
 	"github.com/rayyone/go-core/ryerr"
 	loghelper "github.com/rayyone/go-core/helpers/log"
 )
 
+var logger = loghelper.Named("retry")
+
 type Options struct {
 	DelayBetweenAttempt time.Duration
 	MaxRetry            int
-	attempt             int
+	// Multiplier increases the delay after each attempt (exponential backoff). 0 or 1 keeps it constant
+	Multiplier float64
+	// Jitter randomizes the delay by up to this fraction of it, e.g. 0.5 waits between 50% and 150% of the delay
+	Jitter float64
+	// ShouldRetry retries only the errors it returns true for, the others are returned as is. Nil retries every error.
+	// When set, the last error is also returned as is once the retries run out
+	ShouldRetry func(err error) bool
+	// Ctx stops retrying once it is done, and returns the last error. Nil never stops
+	Ctx     context.Context
+	attempt int
+	lastErr error
 }
 
 func DefaultOptions() Options {
@@ -22,19 +37,27 @@ func DefaultOptions() Options {
 
 func WithRetry(fn func() error, opts Options) error {
 	if opts.attempt > 0 {
-		loghelper.PrintYellowf(
-			"Retrying %d/%d in %.2f s..", opts.attempt, opts.MaxRetry, opts.DelayBetweenAttempt.Seconds(),
-		)
-		time.Sleep(opts.DelayBetweenAttempt)
+		delay := opts.delay()
+		logger.Warn("Retrying", "attempt", opts.attempt, "max_retry", opts.MaxRetry, "delay", delay)
+		if !opts.sleep(delay) {
+			return opts.lastErr
+		}
 	}
 	err := fn()
 	if err != nil {
+		if opts.ShouldRetry != nil && !opts.ShouldRetry(err) {
+			return err
+		}
 		opts.attempt++
+		opts.lastErr = err
 		if opts.attempt > opts.MaxRetry {
+			if opts.ShouldRetry != nil {
+				return err
+			}
 			if opts.MaxRetry > 0 {
 				return ryerr.BadRequest.Newf("Max retry reached. Error: %+v", err)
 			} else {
-				return ryerr.BadRequest.Newf(err.Error())
+				return ryerr.BadRequest.New(err.Error())
 			}
 		} else {
 			return WithRetry(fn, opts)
@@ -43,3 +66,31 @@ func WithRetry(fn func() error, opts Options) error {
 
 	return nil
 }
+
+// sleep waits for the delay, and returns false if Ctx is done before
+func (opts Options) sleep(delay time.Duration) bool {
+	if opts.Ctx == nil {
+		time.Sleep(delay)
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-opts.Ctx.Done():
+		return false
+	}
+}
+
+// delay returns the delay before the current attempt
+func (opts Options) delay() time.Duration {
+	delay := float64(opts.DelayBetweenAttempt)
+	if opts.Multiplier > 1 {
+		delay *= math.Pow(opts.Multiplier, float64(opts.attempt-1))
+	}
+	if opts.Jitter > 0 {
+		delay += delay * opts.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
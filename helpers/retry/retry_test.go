@@ -0,0 +1,41 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errRetryable = errors.New("retryable")
+
+func TestWithRetryReturnsLastError(t *testing.T) {
+	attempts := 0
+	err := WithRetry(func() error {
+		attempts++
+		return errRetryable
+	}, Options{MaxRetry: 2, ShouldRetry: func(err error) bool { return errors.Is(err, errRetryable) }})
+	if err != errRetryable {
+		t.Fatalf("err = %v, want the last error as is", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestWithRetryStopsOnDoneContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	start := time.Now()
+	err := WithRetry(func() error {
+		attempts++
+		cancel()
+		return errRetryable
+	}, Options{DelayBetweenAttempt: time.Minute, MaxRetry: 3, Ctx: ctx})
+	if err != errRetryable {
+		t.Fatalf("err = %v, want the last error", err)
+	}
+	if attempts != 1 || time.Since(start) > time.Second {
+		t.Fatalf("attempts = %d after %s, want 1 without waiting", attempts, time.Since(start))
+	}
+}
@@ -20,7 +20,9 @@ type Slack struct {
 	options          map[string]interface{}
 	optionRWLock     sync.RWMutex
 	maxMessage       int
-	rateLimitMessage map[string]int
+	rateLimitLock    sync.Mutex
+	rateLimitDate    string
+	rateLimitMessage map[string]int // Message count of the day, per group
 }
 
 var (
@@ -47,30 +49,45 @@ func InitSlackClient(config SlackConfig) error {
 	slackClient.api = slack.New(config.Token)
 	slackClient.config = config
 	slackClient.maxMessage = config.MaxMessage
+	slackClient.rateLimitLock.Lock()
+	slackClient.rateLimitDate = ""
 	slackClient.rateLimitMessage = map[string]int{}
+	slackClient.rateLimitLock.Unlock()
 	return nil
 }
 func (s *Slack) SetOption(key string, value interface{}) {
-	s.optionRWLock.RLock()
+	s.optionRWLock.Lock()
 	s.options[key] = value
-	s.optionRWLock.RUnlock()
+	s.optionRWLock.Unlock()
 }
 
 func (s *Slack) RateLimit() bool {
+	return s.RateLimitGroup("")
+}
+
+// RateLimitGroup counts a message of the group and tells if it can be sent.
+// Each group has its own daily budget of MaxMessage messages
+func (s *Slack) RateLimitGroup(group string) bool {
 	if s.maxMessage > 0 {
 		date := time.Now().Format("2006-01-02")
-		s.optionRWLock.RLock()
-		defer s.optionRWLock.RUnlock()
-		rateValue, ok := s.rateLimitMessage[date]
-		if ok && rateValue > s.maxMessage - 1 {
+		s.rateLimitLock.Lock()
+		defer s.rateLimitLock.Unlock()
+		if s.rateLimitDate != date {
+			s.rateLimitDate = date
+			s.rateLimitMessage = map[string]int{}
+		}
+		rateValue := s.rateLimitMessage[group]
+		if rateValue > s.maxMessage-1 {
 			return false
 		}
-		s.rateLimitMessage = map[string]int{date: rateValue + 1}
+		s.rateLimitMessage[group] = rateValue + 1
 	}
 	return true
 }
 
 func (s *Slack) GetOption(key string) interface{} {
+	s.optionRWLock.RLock()
+	defer s.optionRWLock.RUnlock()
 	val, ok := s.options[key]
 	if !ok {
 		return nil
@@ -79,7 +96,12 @@ func (s *Slack) GetOption(key string) interface{} {
 }
 
 func (s *Slack) SendSimpleMessageToChannel(channel string, title string, message string) error {
-	if !s.RateLimit() {
+	return s.SendGroupedMessageToChannel(channel, "", title, message)
+}
+
+// SendGroupedMessageToChannel sends a message counted in the daily budget of the group
+func (s *Slack) SendGroupedMessageToChannel(channel string, group string, title string, message string) error {
+	if !s.RateLimitGroup(group) {
 		return nil
 	}
 	if s.config.Token == "" {
@@ -116,3 +138,20 @@ func SendSimpleMessageToChannel(channel string, title string, message string) er
 	}
 	return slackClient.SendSimpleMessageToChannel(channel, title, message)
 }
+
+func SendGroupedMessage(group string, title string, message string) error {
+	slackClient := CurrentSlackClient()
+	if slackClient.config.Token == "" {
+		fmt.Printf("slack client not init")
+		return nil
+	}
+	return slackClient.SendGroupedMessageToChannel(slackClient.config.DefaultChannel, group, title, message)
+}
+func SendGroupedMessageToChannel(channel string, group string, title string, message string) error {
+	slackClient := CurrentSlackClient()
+	if slackClient.config.Token == "" {
+		fmt.Printf("slack client not init")
+		return nil
+	}
+	return slackClient.SendGroupedMessageToChannel(channel, group, title, message)
+}
@@ -1,5 +1,11 @@
 package mails
 
+import (
+	"context"
+
+	"github.com/rayyone/go-core/ryerr"
+)
+
 type Mailable interface {
 	Subject() string
 	HTMLBody() string
@@ -30,6 +36,12 @@ type MailProvider interface {
 	Send(content Message) error
 }
 
+// ContextMailProvider is a MailProvider whose sending is cancelled with the context, e.g. corecontainer.Request.Ctx
+type ContextMailProvider interface {
+	MailProvider
+	SendCtx(ctx context.Context, content Message) error
+}
+
 type Mailer struct {
 	MailProvider MailProvider
 }
@@ -49,14 +61,34 @@ func (m *Mailer) Send(msg Message) error {
 	return m.MailProvider.Send(msg)
 }
 
-func (m *Mailer) SendMailable(envelope Envelope, mailable Mailable) error {
+// SendCtx sends the message, unless the context is done. The providers implementing ContextMailProvider stop sending it
+// when the context is done
+func (m *Mailer) SendCtx(ctx context.Context, msg Message) error {
+	if provider, ok := m.MailProvider.(ContextMailProvider); ok {
+		return provider.SendCtx(ctx, msg)
+	}
+	if err := ctx.Err(); err != nil {
+		return ryerr.Wrap(err, "Email not sent")
+	}
+	return m.MailProvider.Send(msg)
+}
+
+func mailableMessage(envelope Envelope, mailable Mailable) Message {
 	msg := Message{Envelope: envelope}
 	msg.HTML = mailable.HTMLBody()
 	msg.Text = mailable.TextBody()
 	msg.Subject = mailable.Subject()
 	msg.Header = mailable.Header()
 	msg.Attachments = mailable.Attachments()
-	return m.MailProvider.Send(msg)
+	return msg
+}
+
+func (m *Mailer) SendMailable(envelope Envelope, mailable Mailable) error {
+	return m.MailProvider.Send(mailableMessage(envelope, mailable))
+}
+
+func (m *Mailer) SendMailableCtx(ctx context.Context, envelope Envelope, mailable Mailable) error {
+	return m.SendCtx(ctx, mailableMessage(envelope, mailable))
 }
 func (m *Mailer) MailBuilder() *MailBuilder {
 	var mailBuilder MailBuilder
@@ -118,3 +150,6 @@ func (m *MailBuilder) Attachment(attachment Attachment) *MailBuilder {
 func (m *MailBuilder) Deliver() error {
 	return m.Send(m.Message)
 }
+func (m *MailBuilder) DeliverCtx(ctx context.Context) error {
+	return m.SendCtx(ctx, m.Message)
+}
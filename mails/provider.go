@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/go-mail/mail"
+	loghelper "github.com/rayyone/go-core/helpers/log"
 	"github.com/rayyone/go-core/ryerr"
 )
 
+var logger = loghelper.Named("mails")
+
 type Provider struct{}
 
 func (*Provider) BuildMailMessage(msg Message) (*mail.Message, error) {
@@ -10,7 +10,6 @@ import (
 	loghelper "github.com/rayyone/go-core/helpers/log"
 	"github.com/rayyone/go-core/helpers/method"
 	"github.com/rayyone/go-core/ryerr"
-	"strings"
 	"time"
 )
 
@@ -52,6 +51,10 @@ func NewSESProvider(conf SESConfiguration, from From) *SESProvider {
 }
 
 func (s *SESProvider) Send(msg Message) error {
+	return s.SendCtx(context.Background(), msg)
+}
+
+func (s *SESProvider) SendCtx(ctx context.Context, msg Message) error {
 	if msg.From == nil || msg.From.Address == "" {
 		msg.From = s.config.From
 	}
@@ -66,12 +69,8 @@ func (s *SESProvider) Send(msg Message) error {
 		return err
 	}
 	start := time.Now()
-	loghelper.PrintYellowf(
-		"[SMTP] Sending email to: %s, cc: %s, bcc: %s",
-		strings.Join(msg.To, ", "),
-		strings.Join(msg.Cc, ", "),
-		strings.Join(msg.Bcc, ", "),
-	)
+	logger := loghelper.WithContext(ctx, logger)
+	logger.InfoContext(ctx, "Sending email", "provider", "ses", "to", msg.To, "cc", msg.Cc, "bcc", msg.Bcc)
 	input := &sesv2.SendEmailInput{
 		Content: &types.EmailContent{
 			Raw: &types.RawMessage{
@@ -79,11 +78,11 @@ func (s *SESProvider) Send(msg Message) error {
 			},
 		},
 	}
-	_, err = s.client.SendEmail(context.Background(), input)
+	_, err = s.client.SendEmail(ctx, input)
 	if err != nil {
-		loghelper.PrintRedf("[SMTP] Send email completed with error in %.2fs", time.Since(start).Seconds())
-		return ryerr.New(ryerr.Wrapf(err, err.Error()).Error())
+		logger.ErrorContext(ctx, "Send email failed", "provider", "ses", "duration", time.Since(start), "error", err)
+		return ryerr.New(ryerr.Wrap(err, err.Error()).Error())
 	}
-	loghelper.PrintYellowf("[SMTP] Send email completed in %.2fs", time.Since(start).Seconds())
+	logger.InfoContext(ctx, "Email sent", "provider", "ses", "duration", time.Since(start))
 	return err
 }
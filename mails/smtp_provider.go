@@ -1,11 +1,11 @@
 package mails
 
 import (
+	"context"
 	"github.com/go-mail/mail"
 	loghelper "github.com/rayyone/go-core/helpers/log"
 	"github.com/rayyone/go-core/helpers/method"
 	"github.com/rayyone/go-core/ryerr"
-	"strings"
 	"time"
 )
 
@@ -29,6 +29,11 @@ func NewSMTPMailer(conf SMTPConfiguration, from From) *SMTPProvider {
 }
 
 func (s *SMTPProvider) Send(msg Message) error {
+	return s.SendCtx(context.Background(), msg)
+}
+
+// SendCtx sends the message, unless the context is done. Once the connection is open, the sending isn't cancelled
+func (s *SMTPProvider) SendCtx(ctx context.Context, msg Message) error {
 
 	port := 587
 	if s.config.Port != 0 {
@@ -50,18 +55,17 @@ func (s *SMTPProvider) Send(msg Message) error {
 		Timeout:      30 * time.Second,
 		RetryFailure: true,
 	}
+	if err := ctx.Err(); err != nil {
+		return ryerr.Wrap(err, "Email not sent")
+	}
 	start := time.Now()
-	loghelper.PrintYellowf(
-		"[SMTP] Sending email to: %s, cc: %s, bcc: %s",
-		strings.Join(msg.To, ", "),
-		strings.Join(msg.Cc, ", "),
-		strings.Join(msg.Bcc, ", "),
-	)
+	logger := loghelper.WithContext(ctx, logger)
+	logger.InfoContext(ctx, "Sending email", "provider", "smtp", "to", msg.To, "cc", msg.Cc, "bcc", msg.Bcc)
 	if err := dialer.DialAndSend(mailMsg); err != nil {
-		loghelper.PrintRedf("[SMTP] Send email completed with error in %.2fs", time.Since(start).Seconds())
-		return ryerr.New(ryerr.Wrapf(err, err.Error()).Error())
+		logger.ErrorContext(ctx, "Send email failed", "provider", "smtp", "duration", time.Since(start), "error", err)
+		return ryerr.New(ryerr.Wrap(err, err.Error()).Error())
 	}
-	loghelper.PrintYellowf("[SMTP] Send email completed in %.2fs", time.Since(start).Seconds())
+	logger.InfoContext(ctx, "Email sent", "provider", "smtp", "duration", time.Since(start))
 
 	return nil
 }
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	loghelper "github.com/rayyone/go-core/helpers/log"
+	nethelper "github.com/rayyone/go-core/helpers/net"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// PathRedaction replaces the parts of the request path matching Pattern, e.g. the token of /password-resets/<token>
+type PathRedaction struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+type AccessLogOptions struct {
+	// Logger default to the "access" child logger
+	Logger *slog.Logger
+	// SlowThreshold logs the requests taking longer at warn level, with slow=true. 0 disables it
+	SlowThreshold time.Duration
+	// SkipPaths are not logged, e.g. health checks
+	SkipPaths []string
+	// PathRedactions are applied to the request path. Query params registered as sensitive (see ryerr.RegisterSensitive) are redacted too
+	PathRedactions []PathRedaction
+	// Headers are the request headers logged
+	Headers []string
+	// RedactHeaders are logged with a redacted value (case insensitive)
+	RedactHeaders []string
+}
+
+func DefaultAccessLogOptions() AccessLogOptions {
+	return AccessLogOptions{
+		SlowThreshold: time.Second,
+		Headers:       []string{"User-Agent", "Referer"},
+		RedactHeaders: []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+	}
+}
+
+// AccessLog Middleware for logging one structured entry per request, see AccessLogWithOptions
+func AccessLog() gin.HandlerFunc {
+	return AccessLogWithOptions(DefaultAccessLogOptions())
+}
+
+// AccessLogWithOptions Middleware for logging one structured entry per request: method, route template, path, status,
+// latency, bytes in/out, client IP, user, request ID and the type/code of the error responded.
+// Server errors are logged at error level, slow requests at warn level. Use it in place of gin.Logger()
+func AccessLogWithOptions(options AccessLogOptions) gin.HandlerFunc {
+	logger := options.Logger
+	if logger == nil {
+		logger = loghelper.Named("access")
+	}
+	skipPaths := make(map[string]bool, len(options.SkipPaths))
+	for _, path := range options.SkipPaths {
+		skipPaths[path] = true
+	}
+	redactHeaders := make(map[string]bool, len(options.RedactHeaders))
+	for _, header := range options.RedactHeaders {
+		redactHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+
+	return func(c *gin.Context) {
+		if skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+		start := time.Now()
+		body := &countingReader{ReadCloser: c.Request.Body}
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = body
+		}
+
+		c.Next()
+
+		latency := time.Since(start)
+		status := c.Writer.Status()
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", redactPath(c.Request.URL, options.PathRedactions),
+			"status", status,
+			"latency", latency,
+			"bytes_in", max(c.Request.ContentLength, body.count.Load()),
+			"bytes_out", max(c.Writer.Size(), 0),
+		}
+		if ip := nethelper.GetIPAddress(c.Request); ip != nil {
+			attrs = append(attrs, "client_ip", *ip)
+		}
+		if scope := ryerr.ScopeFromContext(c.Request.Context()); scope != nil {
+			if user := scope.User(); user != nil && user.ID != "" {
+				attrs = append(attrs, "user_id", user.ID)
+			}
+		}
+		var customErr ryerr.Err
+		if err := c.Errors.Last(); err != nil && errors.As(err.Err, &customErr) {
+			attrs = append(attrs, "error_type", uint(ryerr.GetType(err.Err)))
+			if code, ok := ryerr.GetCode(err.Err); ok {
+				attrs = append(attrs, "error_code", code.Code)
+			}
+		}
+		for _, header := range options.Headers {
+			header = http.CanonicalHeaderKey(header)
+			if value := c.GetHeader(header); value != "" {
+				if redactHeaders[header] {
+					value = ryerr.RedactedValue
+				}
+				attrs = append(attrs, "header."+header, value)
+			}
+		}
+
+		level := slog.LevelInfo
+		if options.SlowThreshold > 0 && latency >= options.SlowThreshold {
+			level = slog.LevelWarn
+			attrs = append(attrs, "slow", true)
+		}
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		ctx := c.Request.Context()
+		loghelper.WithContext(ctx, logger).Log(ctx, level, "Request", attrs...)
+	}
+}
+
+// redactPath applies the redactions to the path and redacts the sensitive query params
+func redactPath(u *url.URL, redactions []PathRedaction) string {
+	path := u.Path
+	for _, redaction := range redactions {
+		path = redaction.Pattern.ReplaceAllString(path, redaction.Replacement)
+	}
+	if u.RawQuery == "" {
+		return path
+	}
+	query := u.Query()
+	for key := range query {
+		if ryerr.IsSensitive(key) {
+			query[key] = []string{ryerr.RedactedValue}
+		}
+	}
+	return path + "?" + strings.ReplaceAll(query.Encode(), url.QueryEscape(ryerr.RedactedValue), ryerr.RedactedValue)
+}
+
+// countingReader counts the bytes of the request body read by the handlers
+type countingReader struct {
+	io.ReadCloser
+	count atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rayyone/go-core/authz"
+	corecontainer "github.com/rayyone/go-core/container"
+	"github.com/rayyone/go-core/helpers/jwt"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// TokenExtractor returns the token of the request, an empty string if there is none
+type TokenExtractor func(c *gin.Context) string
+
+// BearerToken extracts the token of the "Authorization: Bearer <token>" header
+func BearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+type AuthOptions struct {
+	Verifier *jwt.Verifier
+	// Optional lets the requests without token through, unauthenticated. Requests with an invalid token are still rejected
+	Optional bool
+	// TokenExtractor default to BearerToken
+	TokenExtractor TokenExtractor
+}
+
+// Authenticate Middleware for authenticating the requests with a bearer token, see AuthenticateWithOptions
+func Authenticate(verifier *jwt.Verifier) gin.HandlerFunc {
+	return AuthenticateWithOptions(AuthOptions{Verifier: verifier})
+}
+
+// AuthenticateWithOptions Middleware for authenticating the requests with a signed token (JWT).
+// The claims of a valid token are set as corecontainer.Auth in the gin and request contexts (see corecontainer.GetAuth),
+// and the subject is set as the user of the error reports. Missing or invalid tokens are responded with a ryerr.Unauthorized error
+func AuthenticateWithOptions(options AuthOptions) gin.HandlerFunc {
+	if options.TokenExtractor == nil {
+		options.TokenExtractor = BearerToken
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		token := options.TokenExtractor(c)
+		if token == "" {
+			if options.Optional {
+				c.Next()
+				return
+			}
+			respondError(c, ryerr.Unauthorized.NewCtx(ctx, "Missing authentication token"))
+			c.Abort()
+			return
+		}
+
+		claims, err := options.Verifier.Verify(ctx, token)
+		if err != nil {
+			respondError(c, err)
+			c.Abort()
+			return
+		}
+
+		auth := corecontainer.NewAuth(claims)
+		if scope := ryerr.ScopeFromContext(ctx); scope != nil {
+			user := ryerr.User{}
+			if current := scope.User(); current != nil {
+				user = *current
+			}
+			user.ID = auth.UserID()
+			scope.SetUser(user)
+		}
+		c.Set(corecontainer.AuthGinKey, auth)
+		c.Request = c.Request.WithContext(corecontainer.WithAuth(ctx, auth))
+
+		c.Next()
+	}
+}
+
+// RequireScope Middleware for rejecting the requests whose token doesn't grant every scope, with a ryerr.Forbidden error.
+// Use it after Authenticate
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := corecontainer.GetAuth(c)
+		if !auth.IsAuthenticated() {
+			respondError(c, ryerr.Unauthorized.NewCtx(c.Request.Context(), "Missing authentication token"))
+			c.Abort()
+			return
+		}
+		if !auth.HasAllScopes(scopes...) {
+			respondError(c, ryerr.Forbidden.NewCtx(c.Request.Context(), "Insufficient scope"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission Middleware for rejecting the requests whose user isn't granted every permission ("action:resource"),
+// with a ryerr.Forbidden error. A permission granted on the user's own resources only ("action:resource:own") lets the
+// request through: check the resource itself with authz.Can in the handler. Use it after Authenticate
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := corecontainer.GetAuth(c)
+		for _, permission := range permissions {
+			action, resource, _ := strings.Cut(permission, ":")
+			if err := authz.CheckPermission(c.Request.Context(), auth, action, resource); err != nil {
+				respondError(c, err)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rayyone/go-core/helpers/requestid"
+)
+
+type CORSConfig struct {
+	// AllowOrigins are the allowed origins: "*" allows any origin, "https://*.example.com" allows the subdomains.
+	// "*" is only allowed alone or as the first host label
+	AllowOrigins []string
+	// AllowOriginFunc allows the origins it returns true for, in addition to AllowOrigins
+	AllowOriginFunc func(origin string) bool
+	AllowMethods    []string
+	// AllowHeaders are the allowed request headers. "*" allows the headers requested by the preflight
+	AllowHeaders []string
+	// ExposeHeaders are the response headers readable by the browser
+	ExposeHeaders []string
+	// AllowCredentials allows cookies and Authorization. It cannot be used with the "*" origin
+	AllowCredentials bool
+	// MaxAge is the time the browser caches the preflight response. 0 doesn't send it
+	MaxAge time.Duration
+}
+
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions},
+		AllowHeaders:  []string{"Origin", "Accept", "Content-Type", "Authorization", requestid.Header},
+		ExposeHeaders: []string{requestid.Header},
+		MaxAge:        12 * time.Hour,
+	}
+}
+
+type corsOrigins struct {
+	any       bool
+	exact     map[string]bool
+	wildcards [][2]string
+	allow     func(origin string) bool
+}
+
+// newCORSOrigins panics if an origin or the config is invalid
+func newCORSOrigins(config CORSConfig) corsOrigins {
+	res := corsOrigins{exact: map[string]bool{}, allow: config.AllowOriginFunc}
+	for _, origin := range config.AllowOrigins {
+		origin = strings.ToLower(origin)
+		if origin == "*" {
+			if config.AllowCredentials {
+				panic(`middleware.CORS: the "*" origin cannot be allowed with credentials, list the origins instead`)
+			}
+			res.any = true
+		} else if prefix, suffix, found := strings.Cut(origin, "*"); found {
+			if !strings.HasSuffix(prefix, "://") || strings.Count(prefix, "/") != 2 || !strings.HasPrefix(suffix, ".") || strings.ContainsAny(suffix, "*/") {
+				panic(fmt.Sprintf(`middleware.CORS: invalid origin %q, "*" must be the first host label, e.g. "https://*.example.com"`, origin))
+			}
+			res.wildcards = append(res.wildcards, [2]string{prefix, suffix})
+		} else {
+			res.exact[origin] = true
+		}
+	}
+	return res
+}
+
+func (o corsOrigins) isAllowed(origin string) bool {
+	if o.any {
+		return true
+	}
+	lower := strings.ToLower(origin)
+	if o.exact[lower] {
+		return true
+	}
+	for _, wildcard := range o.wildcards {
+		if len(lower) > len(wildcard[0])+len(wildcard[1]) && strings.HasPrefix(lower, wildcard[0]) && strings.HasSuffix(lower, wildcard[1]) &&
+			isSubdomain(lower[len(wildcard[0]):len(lower)-len(wildcard[1])]) {
+			return true
+		}
+	}
+	return o.allow != nil && o.allow(origin)
+}
+
+// isSubdomain checks if the labels matched by "*" are host labels, e.g. "api" or "eu.api"
+func isSubdomain(labels string) bool {
+	for _, label := range strings.Split(labels, ".") {
+		if label == "" {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CORS Middleware for the Cross-Origin Resource Sharing headers, it panics if the config is invalid. Preflight requests
+// are responded here (204, or 403 if the origin isn't allowed) without reaching the handlers. Other requests from an
+// origin not allowed go through without CORS headers, and are blocked by the browser
+func CORS(config CORSConfig) gin.HandlerFunc {
+	origins := newCORSOrigins(config)
+	allowMethods := strings.Join(config.AllowMethods, ", ")
+	allowHeaders := strings.Join(config.AllowHeaders, ", ")
+	anyHeader := false
+	for _, header := range config.AllowHeaders {
+		if header == "*" {
+			anyHeader = true
+		}
+	}
+	exposeHeaders := strings.Join(config.ExposeHeaders, ", ")
+	maxAge := ""
+	if config.MaxAge > 0 {
+		maxAge = strconv.Itoa(int(config.MaxAge.Seconds()))
+	}
+	// "*" cannot be responded when the response depends on the origin
+	reflectOrigin := !origins.any
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		header := c.Writer.Header()
+		if reflectOrigin {
+			header.Add("Vary", "Origin")
+		}
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if !origins.isAllowed(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if reflectOrigin {
+			header.Set("Access-Control-Allow-Origin", origin)
+		} else {
+			header.Set("Access-Control-Allow-Origin", "*")
+		}
+		if config.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			if allowMethods != "" {
+				header.Set("Access-Control-Allow-Methods", allowMethods)
+			}
+			if anyHeader {
+				if requested := c.GetHeader("Access-Control-Request-Headers"); requested != "" {
+					header.Set("Access-Control-Allow-Headers", requested)
+				}
+			} else if allowHeaders != "" {
+				header.Set("Access-Control-Allow-Headers", allowHeaders)
+			}
+			if maxAge != "" {
+				header.Set("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposeHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		c.Next()
+	}
+}
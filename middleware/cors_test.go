@@ -0,0 +1,39 @@
+package middleware
+
+import "testing"
+
+func TestCORSOriginWildcard(t *testing.T) {
+	origins := newCORSOrigins(CORSConfig{AllowOrigins: []string{"https://*.example.com"}})
+	tests := map[string]bool{
+		"https://api.example.com":          true,
+		"https://eu.api.example.com":       true,
+		"https://example.com":              false,
+		"https://.example.com":             false,
+		"https://evil.com/.example.com":    false,
+		"http://api.example.com":           false,
+		"https://api.example.com.evil.com": false,
+	}
+	for origin, want := range tests {
+		if got := origins.isAllowed(origin); got != want {
+			t.Errorf("isAllowed(%q) = %v, want %v", origin, got, want)
+		}
+	}
+}
+
+func TestCORSInvalidConfig(t *testing.T) {
+	configs := map[string]CORSConfig{
+		"wildcard with credentials": {AllowOrigins: []string{"*"}, AllowCredentials: true},
+		"wildcard in the domain":    {AllowOrigins: []string{"https://example.*"}},
+		"wildcard in a label":       {AllowOrigins: []string{"https://api*.example.com"}},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("CORS didn't panic")
+				}
+			}()
+			CORS(config)
+		})
+	}
+}
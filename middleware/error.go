@@ -1,100 +1,113 @@
 package middleware
 
 import (
-	"fmt"
 	"log"
 	"net"
 	"os"
-	"strings"
+	"sync"
 	"syscall"
-	"unicode"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	validator "github.com/go-playground/validator/v10"
+	"github.com/rayyone/go-core/helpers/i18n"
+	"github.com/rayyone/go-core/helpers/method"
 	"github.com/rayyone/go-core/helpers/response"
 	"github.com/rayyone/go-core/ryerr"
 )
 
-func isAllUpper(s string) bool {
-	for _, v := range s {
-		if !unicode.IsUpper(v) {
-			return false
-		}
+// fieldErrorToText translates the validation error in the locale, see i18n.Register to add messages for custom tags
+func fieldErrorToText(e validator.FieldError, locale string) string {
+	params := map[string]string{"field": e.StructField(), "param": e.Param()}
+	if msg := i18n.T(locale, i18n.ValidationKey(e.Tag()), params); msg != "" {
+		return msg
 	}
-	return true
+	return i18n.T(locale, i18n.ValidationKey(i18n.DefaultTag), params)
 }
 
-func lowerCaseFirst(s string) string {
-	if len(s) < 2 {
-		return strings.ToLower(s)
-	}
-	for i, v := range s {
-		return string(unicode.ToLower(v)) + s[i+1:]
-	}
-	return ""
-}
+var useJSONFieldNamesOnce sync.Once
 
-func fieldErrorToText(e validator.FieldError) string {
-	switch e.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", e.Field())
-	case "max":
-		return fmt.Sprintf("%s cannot be longer than or equal to %s", e.Field(), e.Param())
-	case "min":
-		return fmt.Sprintf("%s must be longer than or equal to %s", e.Field(), e.Param())
-	case "email":
-		return fmt.Sprintf("Invalid email format")
-	case "len":
-		return fmt.Sprintf("%s must be %s characters long", e.Field(), e.Param())
-	}
-	return fmt.Sprintf("%s is not valid", e.Field())
+// useJSONFieldNames names the fields of gin binding validation errors after their json tag, see method.FieldPath
+func useJSONFieldNames() {
+	useJSONFieldNamesOnce.Do(func() {
+		if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+			method.RegisterJSONFieldNames(validate)
+		}
+	})
 }
 
+// handleValidationError responds every validation error, keyed by the full json path of its field
 func handleValidationError(e *gin.Error, c *gin.Context) {
 	validationErrs := e.Err.(validator.ValidationErrors)
-	var err error
-	var errMessage string
+	locale := i18n.GetLocale(c)
+	fieldErrors := make([]ryerr.FieldError, 0, len(validationErrs))
 	for _, validationErr := range validationErrs {
-		errMessage = fieldErrorToText(validationErr)
-		if isAllUpper(validationErr.Field()) {
-			err = ryerr.AddErrorContext(err, strings.ToLower(validationErr.Field()), errMessage)
-		} else {
-			err = ryerr.AddErrorContext(err, lowerCaseFirst(validationErr.Field()), errMessage)
-		}
+		fieldErrors = append(fieldErrors, ryerr.FieldError{
+			Field:   method.FieldPath(validationErr),
+			Tag:     validationErr.Tag(),
+			Param:   validationErr.Param(),
+			Message: fieldErrorToText(validationErr, locale),
+		})
 	}
-	response.RespondError(c, ryerr.Validation.New(errMessage))
+
+	var errMessage string
+	if len(fieldErrors) > 0 {
+		errMessage = fieldErrors[0].Message
+	}
+	err := ryerr.Validation.New(errMessage)
+	for _, fieldError := range fieldErrors {
+		err = ryerr.AddFieldError(err, fieldError)
+	}
+	respondError(c, err)
+}
+
+// respondError handles the error (log & report once, see ryerr.Handle) and responds it
+func respondError(c *gin.Context, err error) {
+	ryerr.HandleCtx(c.Request.Context(), err)
+	response.RespondError(c, err)
 }
 
-// HandleError Middleware for handling error
+// HandleError Middleware for handling error. Errors pushed with c.Error are responded with the status of their ryerr type,
+// known errors (gorm, JSON decoding, binding, body size, deadline) are mapped to a typed ryerr error first, see RegisterErrorMapper. It is the boundary where errors are logged and reported in ryerr.HandleAtBoundary mode
 func HandleError() gin.HandlerFunc {
+	useJSONFieldNames()
+
 	return func(c *gin.Context) {
 		c.Next()
 
 		for _, ginErr := range c.Errors {
+			mapper := mapError
+			if ginErr.IsType(gin.ErrorTypeBind) {
+				mapper = mapBindingError
+			}
+			if err, ok := mapper(ginErr.Err); ok {
+				respondError(c, err)
+				continue
+			}
 			switch e := ginErr.Err.(type) {
 			case validator.ValidationErrors:
 				handleValidationError(ginErr, c)
 			case *net.OpError:
 				if se, ok := e.Err.(*os.SyscallError); ok {
 					if se.Err == syscall.EPIPE {
-						response.RespondError(c, ryerr.New("Broken Pipe"))
+						respondError(c, ryerr.New("Broken Pipe"))
 						log.Printf("Error: Broken Pipe | %+v", ginErr)
 					} else if se.Err == syscall.ECONNRESET {
-						response.RespondError(c, ryerr.New("Connection Reset"))
+						respondError(c, ryerr.New("Connection Reset"))
 						log.Printf("Error: Connection Reset | %+v", ginErr)
 					}
 				}
 			default:
 				err := ryerr.Newf("Unknown error. Error: %s", spew.Sdump(e))
-				response.RespondError(c, ryerr.Msg(err, "Unknown error"))
+				respondError(c, ryerr.Msg(err, "Unknown error"))
 				log.Printf("Error: Unknown error | %v", ginErr)
 			}
 		}
 
 		// If there is no response yet, we respond with unhandled response error
 		if len(c.Errors) != 0 && !c.Writer.Written() && c.Writer.Status() != 200 {
-			response.RespondError(c, ryerr.New("Unhandled response."))
+			respondError(c, ryerr.New("Unhandled response."))
 			log.Println("Error: Unhandled response.")
 		}
 	}
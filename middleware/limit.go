@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// BodyLimit Middleware for limiting the size of the request body, per route. A larger Content-Length is responded
+// with a ryerr.RequestEntityTooLarge error right away. Otherwise reading past the limit fails with an *http.MaxBytesError,
+// responded as a ryerr.RequestEntityTooLarge error by HandleError
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			respondError(c, requestEntityTooLarge(&http.MaxBytesError{Limit: limit}, limit))
+			c.Abort()
+			return
+		}
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+
+		c.Next()
+	}
+}
+
+type TimeoutOptions struct {
+	Timeout time.Duration
+	// ErrorType is the type responded when the deadline passes: ryerr.ServiceUnavailable (default) or ryerr.GatewayTimeout
+	ErrorType ryerr.ErrorType
+}
+
+// Timeout Middleware for setting a deadline to the request, see TimeoutWithOptions
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return TimeoutWithOptions(TimeoutOptions{Timeout: timeout})
+}
+
+// TimeoutWithOptions Middleware for setting a deadline to the request, per route. The request context is cancelled when
+// the deadline passes, and the database queries of the request with it (see corecontainer.InitCoreRequest).
+// The handlers must return once their context is done: the request is then responded with a ErrorType error
+func TimeoutWithOptions(options TimeoutOptions) gin.HandlerFunc {
+	if options.ErrorType == 0 {
+		options.ErrorType = ryerr.ServiceUnavailable
+	}
+
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), options.Timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) || c.Writer.Written() {
+			return
+		}
+		// The errors pushed are responded by HandleError
+		for _, ginErr := range c.Errors {
+			if errors.Is(ginErr.Err, context.DeadlineExceeded) {
+				ginErr.Err = withPublicMessage(options.ErrorType, ginErr.Err, "Request timed out.")
+			}
+		}
+		if len(c.Errors) == 0 {
+			respondError(c, withPublicMessage(options.ErrorType, ctx.Err(), "Request timed out."))
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// ErrorMapper converts an error pushed with c.Error into a typed ryerr error. It returns false if it doesn't map the error
+type ErrorMapper func(err error) (error, bool)
+
+var (
+	errorMappersRWLock sync.RWMutex
+	errorMappers       []ErrorMapper
+)
+
+// RegisterErrorMapper registers a mapper used by HandleError. Registered mappers run before the built-in ones,
+// the last registered first
+func RegisterErrorMapper(mapper ErrorMapper) {
+	errorMappersRWLock.Lock()
+	defer errorMappersRWLock.Unlock()
+	errorMappers = append([]ErrorMapper{mapper}, errorMappers...)
+}
+
+// ClearErrorMappers removes the registered mappers. The built-in ones are kept
+func ClearErrorMappers() {
+	errorMappersRWLock.Lock()
+	defer errorMappersRWLock.Unlock()
+	errorMappers = nil
+}
+
+// builtInErrorMappers run after the registered mappers. Typed ryerr errors are passed through before them
+var builtInErrorMappers = []ErrorMapper{
+	mapGormError,
+	mapJSONError,
+	mapMaxBytesError,
+	mapDeadlineError,
+}
+
+// mapError maps the error with the registered mappers, then passes typed ryerr errors through,
+// then maps it with the built-in mappers. Untyped ryerr errors are passed through as is
+func mapError(err error) (error, bool) {
+	errorMappersRWLock.RLock()
+	mappers := errorMappers
+	errorMappersRWLock.RUnlock()
+	for _, mapper := range mappers {
+		if mappedErr, ok := mapper(err); ok {
+			return mappedErr, true
+		}
+	}
+
+	var customErr ryerr.Err
+	isCustomErr := errors.As(err, &customErr)
+	_, hasCode := ryerr.GetCode(err)
+	if isCustomErr && (ryerr.GetType(err) != ryerr.NoType || hasCode) {
+		return err, true
+	}
+	for _, mapper := range builtInErrorMappers {
+		if mappedErr, ok := mapper(err); ok {
+			return mappedErr, true
+		}
+	}
+	return err, isCustomErr
+}
+
+// withPublicMessage types the error and replaces its message by one safe to respond. The error is kept in the chain
+func withPublicMessage(errorType ryerr.ErrorType, err error, msg string) error {
+	return ryerr.Msg(errorType.Wrap(err, msg), msg)
+}
+
+func mapGormError(err error) (error, bool) {
+	switch {
+	case ryerr.IsRecordNotFound(err):
+		return withPublicMessage(ryerr.NotFound, err, "Resource not found."), true
+	case ryerr.IsDuplicateKey(err):
+		return withPublicMessage(ryerr.Conflict, err, "Resource already exists."), true
+	case ryerr.IsForeignKeyViolation(err):
+		return withPublicMessage(ryerr.UnprocessableEntity, err, "Related resource does not exist or is still in use."), true
+	}
+	return nil, false
+}
+
+func mapJSONError(err error) (error, bool) {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return ryerr.BadRequest.Wrapf(err, "Malformed JSON at offset %d", syntaxErr.Offset), true
+	case errors.As(err, &typeErr):
+		msg := fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type)
+		mappedErr := withPublicMessage(ryerr.Validation, err, msg)
+		return ryerr.AddFieldError(mappedErr, ryerr.FieldError{Field: typeErr.Field, Tag: "type", Param: typeErr.Type.String(), Message: msg}), true
+	}
+	return nil, false
+}
+
+// mapBindingError maps the end of the request body while binding it (gin.ErrorTypeBind, see Request.SetPostParams).
+// The EOFs of other sources (e.g. an API call or a file read) are not caused by the request
+func mapBindingError(err error) (error, bool) {
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return withPublicMessage(ryerr.BadRequest, err, "Malformed JSON: unexpected end of the request body"), true
+	case errors.Is(err, io.EOF):
+		return withPublicMessage(ryerr.BadRequest, err, "Request body is empty."), true
+	}
+	return mapError(err)
+}
+
+// requestEntityTooLarge returns the RequestEntityTooLarge error of a body larger than the limit
+func requestEntityTooLarge(err error, limit int64) error {
+	return withPublicMessage(ryerr.RequestEntityTooLarge, err, fmt.Sprintf("Request body must not be larger than %d bytes.", limit))
+}
+
+func mapMaxBytesError(err error) (error, bool) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return requestEntityTooLarge(err, maxBytesErr.Limit), true
+	}
+	return nil, false
+}
+
+// mapDeadlineError maps the queries and calls cancelled by a deadline, e.g. a database statement timeout.
+// The deadline of Timeout is responded with its own type
+func mapDeadlineError(err error) (error, bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return withPublicMessage(ryerr.GatewayTimeout, err, "Request timed out."), true
+	}
+	return nil, false
+}
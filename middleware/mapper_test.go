@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"io"
+	"testing"
+
+	"github.com/rayyone/go-core/ryerr"
+)
+
+func TestMapEOFOnlyWhileBinding(t *testing.T) {
+	if _, ok := mapError(io.EOF); ok {
+		t.Fatal("an EOF not pushed by the binding was mapped")
+	}
+	err, ok := mapBindingError(io.EOF)
+	if !ok || ryerr.GetType(err) != ryerr.BadRequest {
+		t.Fatalf("mapBindingError(io.EOF) = %v, %v, want a BadRequest error", err, ok)
+	}
+}
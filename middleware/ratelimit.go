@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	nethelper "github.com/rayyone/go-core/helpers/net"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+type RateLimitAlgorithm int
+
+const (
+	// TokenBucket allows bursts of Limit requests, the bucket refilling at Limit requests per Period
+	TokenBucket RateLimitAlgorithm = iota
+	// SlidingWindow allows Limit requests in any Period, weighting the previous window by its overlap with the sliding one
+	SlidingWindow
+)
+
+// RateLimitRule is the limit applied to each key
+type RateLimitRule struct {
+	Algorithm RateLimitAlgorithm
+	Limit     int
+	Period    time.Duration
+}
+
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the quota is fully available again
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed. 0 when allowed
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the rate limit state of the keys. Implement it on a shared backend (e.g. Redis)
+// to limit across several instances
+type RateLimitStore interface {
+	// Take consumes one request of the key quota
+	Take(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error)
+}
+
+// RateLimitKeyFunc returns the key a request is limited by. Requests with an empty key are not limited
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// KeyByIP limits each client IP
+func KeyByIP(c *gin.Context) string {
+	if ip := nethelper.GetIPAddress(c.Request); ip != nil {
+		return *ip
+	}
+	return c.ClientIP()
+}
+
+// KeyByRoute limits each route template, whoever the client is
+func KeyByRoute(c *gin.Context) string {
+	return c.Request.Method + " " + c.FullPath()
+}
+
+// KeyByIPAndRoute limits each client IP on each route template
+func KeyByIPAndRoute(c *gin.Context) string {
+	return KeyByIP(c) + "|" + KeyByRoute(c)
+}
+
+type RateLimitOptions struct {
+	RateLimitRule
+	// Name prefixes the keys, so several limits can share a store. Default to "ratelimit"
+	Name string
+	// KeyFunc default to KeyByIP
+	KeyFunc RateLimitKeyFunc
+	// Store default to a new MemoryRateLimitStore
+	Store RateLimitStore
+}
+
+// RateLimit Middleware for limiting the request rate. Requests over the limit are responded with a ryerr.TooManyRequests error.
+// The RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers are set on every response, Retry-After on the limited ones.
+// If the store fails, the request is let through and the error is handled. It panics if the rule is invalid
+func RateLimit(options RateLimitOptions) gin.HandlerFunc {
+	if options.Limit <= 0 || options.Period <= 0 {
+		panic(fmt.Sprintf("middleware.RateLimit: the rule must have a positive limit and period, got %d per %s", options.Limit, options.Period))
+	}
+	if options.Name == "" {
+		options.Name = "ratelimit"
+	}
+	if options.KeyFunc == nil {
+		options.KeyFunc = KeyByIP
+	}
+	if options.Store == nil {
+		options.Store = NewMemoryRateLimitStore()
+	}
+
+	return func(c *gin.Context) {
+		key := options.KeyFunc(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+		result, err := options.Store.Take(ctx, options.Name+":"+key, options.RateLimitRule)
+		if err != nil {
+			ryerr.HandleCtx(ctx, ryerr.Wrap(err, "Rate limit store error"))
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			respondError(c, ryerr.TooManyRequests.NewCtx(ctx, "Too many requests."))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type rateLimitEntry struct {
+	// tokens and updatedAt hold the token bucket state
+	tokens    float64
+	updatedAt time.Time
+	// windowStart, count and prevCount hold the sliding window state
+	windowStart time.Time
+	count       int
+	prevCount   int
+
+	expiresAt time.Time
+}
+
+// MemoryRateLimitStore keeps the rate limit state in memory. It only limits within one instance
+type MemoryRateLimitStore struct {
+	lock      sync.Mutex
+	entries   map[string]*rateLimitEntry
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		entries:   map[string]*rateLimitEntry{},
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	if rule.Limit <= 0 || rule.Period <= 0 {
+		return RateLimitResult{}, ryerr.NewAndDontReport("Rate limit rule must have a positive limit and period")
+	}
+	now := time.Now()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sweep(now)
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &rateLimitEntry{tokens: float64(rule.Limit), updatedAt: now, windowStart: now}
+		s.entries[key] = entry
+	}
+	if rule.Algorithm == SlidingWindow {
+		return entry.takeSlidingWindow(now, rule), nil
+	}
+	return entry.takeTokenBucket(now, rule), nil
+}
+
+func (e *rateLimitEntry) takeTokenBucket(now time.Time, rule RateLimitRule) RateLimitResult {
+	perToken := rule.Period / time.Duration(rule.Limit)
+	e.tokens = math.Min(float64(rule.Limit), e.tokens+float64(now.Sub(e.updatedAt))/float64(perToken))
+	e.updatedAt = now
+
+	result := RateLimitResult{Limit: rule.Limit}
+	if e.tokens >= 1 {
+		e.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - e.tokens) * float64(perToken))
+	}
+	result.Remaining = int(e.tokens)
+	result.Reset = time.Duration((float64(rule.Limit) - e.tokens) * float64(perToken))
+	e.expiresAt = now.Add(result.Reset)
+	return result
+}
+
+func (e *rateLimitEntry) takeSlidingWindow(now time.Time, rule RateLimitRule) RateLimitResult {
+	elapsed := now.Sub(e.windowStart)
+	if elapsed >= rule.Period {
+		windows := elapsed / rule.Period
+		e.prevCount = 0
+		if windows == 1 {
+			e.prevCount = e.count
+		}
+		e.count = 0
+		e.windowStart = e.windowStart.Add(windows * rule.Period)
+		elapsed = now.Sub(e.windowStart)
+	}
+	// Share of the previous window still covered by the sliding window
+	prevWeight := 1 - float64(elapsed)/float64(rule.Period)
+	estimated := float64(e.prevCount)*prevWeight + float64(e.count)
+
+	result := RateLimitResult{Limit: rule.Limit, Reset: rule.Period - elapsed}
+	if estimated+1 <= float64(rule.Limit) {
+		e.count++
+		estimated++
+		result.Allowed = true
+	} else if e.prevCount > 0 && float64(e.count)+1 <= float64(rule.Limit) {
+		// Wait until enough of the previous window slides out
+		needed := (estimated + 1 - float64(rule.Limit)) / float64(e.prevCount)
+		result.RetryAfter = time.Duration(needed * float64(rule.Period))
+	} else {
+		result.RetryAfter = rule.Period - elapsed
+	}
+	result.Remaining = max(rule.Limit-int(math.Ceil(estimated)), 0)
+	if e.count > 0 {
+		result.Reset = 2*rule.Period - elapsed
+	}
+	e.expiresAt = e.windowStart.Add(2 * rule.Period)
+	return result
+}
+
+// sweep forgets the expired entries, at most once a minute
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitInvalidRule(t *testing.T) {
+	rules := map[string]RateLimitRule{
+		"no limit":  {Period: time.Minute},
+		"no period": {Limit: 10},
+	}
+	for name, rule := range rules {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("RateLimit didn't panic")
+				}
+			}()
+			RateLimit(RateLimitOptions{RateLimitRule: rule})
+		})
+	}
+}
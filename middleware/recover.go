@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rayyone/go-core/helpers/response"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// isBrokenConnection Check if the error comes from a client that has gone away
+func isBrokenConnection(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// Recover Middleware for recovering from panics. The panic becomes an ryerr.InternalServer error holding the panic value,
+// which is handled (logged & reported) like any other error and responded with the standard error response.
+// Use it in place of gin.Recovery(), after ReportScope() so the report carries the request scope
+func Recover() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// http.ErrAbortHandler is the way to abort a response on purpose, net/http handles it silently
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			recErr, isErr := rec.(error)
+			if isErr && isBrokenConnection(recErr) {
+				// The client is gone: there is nobody to respond to and nothing worth reporting. Not pushed with c.Error,
+				// HandleError would report and respond it
+				log.Printf("Error: Broken connection | %v", recErr)
+				c.Abort()
+				return
+			}
+
+			ctx := c.Request.Context()
+			if ryerr.ScopeFromContext(ctx) == nil {
+				ctx = ryerr.WithScope(ctx)
+			}
+			ryerr.SetExtraCtx(ctx, "panic_value", fmt.Sprintf("%#v", rec))
+			ryerr.SetExtraCtx(ctx, "goroutine_stack", string(debug.Stack()))
+
+			var err error
+			if isErr {
+				err = ryerr.InternalServer.Wrap(recErr, "Panic")
+			} else {
+				err = ryerr.InternalServer.NewfCtx(ctx, "Panic: %v", rec)
+			}
+			ryerr.HandleCtx(ctx, err)
+
+			c.Abort()
+			if c.Writer.Written() {
+				return
+			}
+			// Don't leak the panic value to the client
+			response.RespondError(c, ryerr.Msg(err, "Internal server error."))
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecoverBrokenConnection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var errs int
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = len(c.Errors)
+	})
+	router.Use(Recover())
+	router.GET("/", func(c *gin.Context) {
+		panic(&net.OpError{Op: "write", Err: os.NewSyscallError("write", syscall.EPIPE)})
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if errs != 0 {
+		t.Errorf("%d errors pushed for a broken connection, expected none", errs)
+	}
+}
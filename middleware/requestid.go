@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rayyone/go-core/helpers/requestid"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// RequestID Middleware for correlating everything done for a request. It takes the X-Request-ID header of the request,
+// or generates an ID if it is missing or invalid, and:
+//   - stores it in the request context (see requestid.FromContext) and the gin context
+//   - tags the error reports of the request with it
+//   - echoes it in the X-Request-ID response header
+//
+// Pass the request context to httpclient (httpclient.Context) to forward it to outgoing calls
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.IsValid(id) {
+			id = requestid.New()
+		}
+
+		ctx := ryerr.WithScope(requestid.NewContext(c.Request.Context(), id))
+		ryerr.SetTagCtx(ctx, "request_id", id)
+		c.Set(requestid.GinKey, id)
+		c.Set(ryerr.ScopeGinKey, ryerr.ScopeFromContext(ctx))
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/getsentry/sentry-go"
+	"github.com/gin-gonic/gin"
+	nethelper "github.com/rayyone/go-core/helpers/net"
+	"github.com/rayyone/go-core/ryerr"
+)
+
+// ReportScope Middleware for scoping error reports to the request.
+// Extras, tags, user and breadcrumbs set with ryerr.SetExtraCtx... on the request context only go to the reports of this request
+func ReportScope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := ryerr.WithScope(c.Request.Context())
+		if sentry.GetHubFromContext(ctx) == nil {
+			ctx = sentry.SetHubOnContext(ctx, sentry.CurrentHub().Clone())
+		}
+		sentry.GetHubFromContext(ctx).Scope().SetRequest(c.Request)
+
+		scope := ryerr.ScopeFromContext(ctx)
+		if ip := nethelper.GetIPAddress(c.Request); ip != nil {
+			scope.SetUser(ryerr.User{IPAddress: *ip})
+		}
+		c.Set(ryerr.ScopeGinKey, scope)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecureHeadersOptions An empty value doesn't send the header
+type SecureHeadersOptions struct {
+	// HSTSMaxAge is the max-age of Strict-Transport-Security. 0 doesn't send it
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	ContentSecurityPolicy string
+	// FrameOptions is X-Frame-Options: DENY or SAMEORIGIN
+	FrameOptions   string
+	ReferrerPolicy string
+	// ContentTypeNosniff sends X-Content-Type-Options: nosniff
+	ContentTypeNosniff bool
+}
+
+// DefaultSecureHeadersOptions are suited to APIs: nothing can be loaded nor framed from the responses
+func DefaultSecureHeadersOptions() SecureHeadersOptions {
+	return SecureHeadersOptions{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+		FrameOptions:          "DENY",
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+		ContentTypeNosniff:    true,
+	}
+}
+
+// SecureHeaders Middleware for the security headers, see SecureHeadersWithOptions
+func SecureHeaders() gin.HandlerFunc {
+	return SecureHeadersWithOptions(DefaultSecureHeadersOptions())
+}
+
+// SecureHeadersWithOptions Middleware for the security headers: HSTS, CSP, X-Content-Type-Options, Referrer-Policy and
+// X-Frame-Options. They are set before the handlers, which can override them
+func SecureHeadersWithOptions(options SecureHeadersOptions) gin.HandlerFunc {
+	headers := map[string]string{}
+	if options.HSTSMaxAge > 0 {
+		hsts := "max-age=" + strconv.Itoa(int(options.HSTSMaxAge.Seconds()))
+		if options.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if options.HSTSPreload {
+			hsts += "; preload"
+		}
+		headers["Strict-Transport-Security"] = hsts
+	}
+	if options.ContentSecurityPolicy != "" {
+		headers["Content-Security-Policy"] = options.ContentSecurityPolicy
+	}
+	if options.FrameOptions != "" {
+		headers["X-Frame-Options"] = options.FrameOptions
+	}
+	if options.ReferrerPolicy != "" {
+		headers["Referrer-Policy"] = options.ReferrerPolicy
+	}
+	if options.ContentTypeNosniff {
+		headers["X-Content-Type-Options"] = "nosniff"
+	}
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		for key, value := range headers {
+			header.Set(key, value)
+		}
+
+		c.Next()
+	}
+}
@@ -12,10 +12,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// RowScope restricts the rows a request can read, update or delete, e.g. "owner_id = current user"
+type RowScope func(r corecontainer.RequestInf, db *gorm.DB) *gorm.DB
+
 // CoreGormRepository Base Repo
 type CoreGormRepository struct {
 	BaseQuery   func(r corecontainer.RequestInf) *gorm.DB
 	IsDebugging bool
+	// RowScopes apply to the reads, updates and deletes. Not to Create, Save and Load, see WithRowScope.
+	// The updates and deletes of rows denied by the scopes, or not found, return a Forbidden error
+	RowScopes []RowScope
 }
 
 // NewCoreGormRepository Initiates new base repo
@@ -42,16 +48,72 @@ func (br *CoreGormRepository) Preload(column string, conditions ...interface{})
 	newCoreGormRepository.BaseQuery = func(r corecontainer.RequestInf) *gorm.DB {
 		return br.BaseQuery(r).Preload(column, conditions...)
 	}
+	newCoreGormRepository.RowScopes = br.RowScopes
 
 	return newCoreGormRepository
 }
 
+// WithRowScope returns a repo applying the row scope to the reads, updates and deletes.
+// Create and Save are not scoped: check the permission of the request before them (see authz.Can)
+func (br *CoreGormRepository) WithRowScope(scope RowScope) *CoreGormRepository {
+	newCoreGormRepository := *br
+	newCoreGormRepository.RowScopes = append(br.RowScopes[:len(br.RowScopes):len(br.RowScopes)], scope)
+
+	return &newCoreGormRepository
+}
+
+func (br *CoreGormRepository) applyRowScopes(r corecontainer.RequestInf, db *gorm.DB) *gorm.DB {
+	for _, scope := range br.RowScopes {
+		db = scope(r, db)
+	}
+	return db
+}
+
+// query returns the base query restricted by the row scopes
+func (br *CoreGormRepository) query(r corecontainer.RequestInf) *gorm.DB {
+	return br.applyRowScopes(r, br.BaseQuery(r))
+}
+
+// checkScopedDelete returns a Forbidden error when the row scopes are set and the delete affected no row:
+// the scopes denied it, or the rows don't exist
+func (br *CoreGormRepository) checkScopedDelete(tx *gorm.DB) error {
+	if len(br.RowScopes) > 0 && tx.RowsAffected == 0 {
+		return errScopedWrite()
+	}
+	return nil
+}
+
+// checkScopedUpdate returns a Forbidden error when the row scopes are set and no row matches the update conditions.
+// The affected rows can't tell: MySQL doesn't count the rows updated with the same values
+func (br *CoreGormRepository) checkScopedUpdate(r corecontainer.RequestInf, tx *gorm.DB) error {
+	if len(br.RowScopes) == 0 || tx.RowsAffected > 0 {
+		return nil
+	}
+	// The conditions of the update: the row scopes, the where, the primary key of the model and the soft delete
+	query := DefaultBaseQuery(r).Table(tx.Statement.Table)
+	if where, ok := tx.Statement.Clauses["WHERE"]; ok {
+		query = query.Clauses(where.Expression)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return ryerr.NewWrapf(err, "Base Repo [checkScopedUpdate] Error")
+	}
+	if count == 0 {
+		return errScopedWrite()
+	}
+	return nil
+}
+
+func errScopedWrite() error {
+	return ryerr.Forbidden.New("You are not allowed to modify this record, or it doesn't exist.")
+}
+
 // Create records by a given condition. out *interface
 func (br *CoreGormRepository) Create(r corecontainer.RequestInf, out interface{}) (*gorm.DB, error) {
 	tx := DefaultBaseQuery(r).Create(out)
 	err := tx.Error
 	if err != nil {
-		err = ryerr.Newf("Base Repo [Create] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [Create] Error")
 		if br.IsDebugging {
 			return tx, err
 		} else {
@@ -63,51 +125,51 @@ func (br *CoreGormRepository) Create(r corecontainer.RequestInf, out interface{}
 
 // FindBy Find one record by a given condition
 func (br *CoreGormRepository) FindBy(r corecontainer.RequestInf, out interface{}, where string, args ...interface{}) (*gorm.DB, error) {
-	tx := br.BaseQuery(r).Where(where, args...).Find(out)
+	tx := br.query(r).Where(where, args...).Find(out)
 	err := GetFindByErrorType(tx.Error, br.IsDebugging)
 
 	return tx, err
 }
 
 func (br *CoreGormRepository) FirstBy(r corecontainer.RequestInf, out interface{}, where string, args ...interface{}) (*gorm.DB, error) {
-	tx := br.BaseQuery(r).Where(where, args...).First(out)
+	tx := br.query(r).Where(where, args...).First(out)
 	return tx, tx.Error
 }
 
 // FindByID Find one record by ID
 func (br *CoreGormRepository) FindByID(r corecontainer.RequestInf, out interface{}, id interface{}) (*gorm.DB, error) {
-	tx := br.BaseQuery(r).Where("id = ?", id).First(out)
+	tx := br.query(r).Where("id = ?", id).First(out)
 	return tx, tx.Error
 }
 
 // Update Update model. model *interface, field: not pointer
 func (br *CoreGormRepository) Update(r corecontainer.RequestInf, model interface{}, fields interface{}) (*gorm.DB, error) {
-	tx := DefaultBaseQuery(r).Model(model).Updates(fields)
+	tx := br.applyRowScopes(r, DefaultBaseQuery(r)).Model(model).Updates(fields)
 	err := tx.Error
 	if err != nil {
-		err = ryerr.Newf("Base Repo [Update] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [Update] Error")
 		if br.IsDebugging {
 			return tx, err
 		} else {
 			return tx, ryerr.Msg(err, "Something went wrong. Please try again later")
 		}
 	}
-	return tx, nil
+	return tx, br.checkScopedUpdate(r, tx)
 }
 
 // UpdateWhere Update by a given condition
 func (br *CoreGormRepository) UpdateWhere(r corecontainer.RequestInf, model interface{}, fields interface{}, where string, args ...interface{}) (*gorm.DB, error) {
-	tx := DefaultBaseQuery(r).Model(model).Where(where, args...).Updates(fields)
+	tx := br.applyRowScopes(r, DefaultBaseQuery(r)).Model(model).Where(where, args...).Updates(fields)
 	err := tx.Error
 	if err != nil {
-		err = ryerr.Newf("Base Repo [UpdateWhere] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [UpdateWhere] Error")
 		if br.IsDebugging {
 			return tx, err
 		} else {
 			return tx, ryerr.Msg(err, "Something went wrong. Please try again later")
 		}
 	}
-	return tx, nil
+	return tx, br.checkScopedUpdate(r, tx)
 }
 
 // Save Update model if ID is present / Create if not. model *interface
@@ -115,7 +177,7 @@ func (br *CoreGormRepository) Save(r corecontainer.RequestInf, model interface{}
 	tx := DefaultBaseQuery(r).Save(model)
 	err := tx.Error
 	if err != nil {
-		err = ryerr.Newf("Base Repo [Save] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [Save] Error")
 		if br.IsDebugging {
 			return tx, err
 		} else {
@@ -127,40 +189,40 @@ func (br *CoreGormRepository) Save(r corecontainer.RequestInf, model interface{}
 
 // DeleteWhere Delete by a given condition
 func (br *CoreGormRepository) DeleteWhere(r corecontainer.RequestInf, model interface{}, where string, args ...interface{}) (*gorm.DB, error) {
-	tx := DefaultBaseQuery(r).Where(where, args...).Delete(model)
+	tx := br.applyRowScopes(r, DefaultBaseQuery(r)).Where(where, args...).Delete(model)
 	err := tx.Error
 	if err != nil {
-		err = ryerr.Newf("Base Repo [DeleteWhere] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [DeleteWhere] Error")
 		if br.IsDebugging {
 			return tx, err
 		} else {
 			return tx, ryerr.Msg(err, "Something went wrong. Please try again later")
 		}
 	}
-	return tx, nil
+	return tx, br.checkScopedDelete(tx)
 }
 
 // ForceDeleteWhere Delete by a given condition & ignore soft deletes
 func (br *CoreGormRepository) ForceDeleteWhere(r corecontainer.RequestInf, model interface{}, where string, args ...interface{}) (*gorm.DB, error) {
-	tx := DefaultBaseQuery(r).Unscoped().Where(where, args...).Delete(model)
+	tx := br.applyRowScopes(r, DefaultBaseQuery(r)).Unscoped().Where(where, args...).Delete(model)
 	err := tx.Error
 	if err != nil {
-		err = ryerr.Newf("Base Repo [ForceDeleteWhere] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [ForceDeleteWhere] Error")
 		if br.IsDebugging {
 			return tx, err
 		} else {
 			return tx, ryerr.Msg(err, "Something went wrong. Please try again later")
 		}
 	}
-	return tx, nil
+	return tx, br.checkScopedDelete(tx)
 }
 
 // Pluck model, out *[]interface
 func (br *CoreGormRepository) Pluck(r corecontainer.RequestInf, model interface{}, out interface{}, col string, where string, args ...interface{}) (*gorm.DB, error) {
-	tx := br.BaseQuery(r).Model(model).Where(where, args...).Pluck(col, out)
+	tx := br.query(r).Model(model).Where(where, args...).Pluck(col, out)
 	err := tx.Error
 	if err != nil {
-		err = ryerr.Newf("Base Repo [Pluck] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [Pluck] Error")
 		if br.IsDebugging {
 			return tx, err
 		} else {
@@ -174,7 +236,7 @@ func (br *CoreGormRepository) Pluck(r corecontainer.RequestInf, model interface{
 func (br *CoreGormRepository) Load(r corecontainer.RequestInf, model interface{}, out interface{}, rel string) error {
 	err := br.BaseQuery(r).Model(model).Select("*").Association(rel).Find(out)
 	if err != nil {
-		err = ryerr.Newf("Base Repo [Load] Error: %s", err)
+		err = ryerr.NewWrapf(err, "Base Repo [Load] Error")
 		if br.IsDebugging {
 			return err
 		} else {
@@ -212,7 +274,7 @@ func GetFindByErrorType(err error, isDebugging bool) error {
 			}
 		} else {
 			_, _, fnName := method.TraceCaller(3)
-			err = ryerr.Newf("Repo [%s] Error: %s", fnName, err)
+			err = ryerr.NewWrapf(err, "Repo [%s] Error", fnName)
 			if isDebugging {
 				return err
 			} else {
@@ -0,0 +1,91 @@
+package corerp
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	corecontainer "github.com/rayyone/go-core/container"
+	"github.com/rayyone/go-core/ryerr"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakeDriver affects no row on every statement, and counts matchingRows on every query
+type fakeDriver struct {
+	matchingRows int64
+}
+
+func (f *fakeDriver) Open(string) (driver.Conn, error)             { return fakeConn{f}, nil }
+func (f *fakeDriver) Connect(context.Context) (driver.Conn, error) { return fakeConn{f}, nil }
+func (f *fakeDriver) Driver() driver.Driver                        { return f }
+
+type fakeConn struct{ driver *fakeDriver }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type fakeStmt struct{ driver *fakeDriver }
+
+func (s fakeStmt) Close() error                               { return nil }
+func (s fakeStmt) NumInput() int                              { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) { return driver.RowsAffected(0), nil }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &countRows{count: s.driver.matchingRows}, nil
+}
+
+type countRows struct {
+	count int64
+	done  bool
+}
+
+func (r *countRows) Columns() []string { return []string{"count"} }
+func (r *countRows) Close() error      { return nil }
+func (r *countRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.count
+	return nil
+}
+
+type scopedModel struct {
+	ID      uint
+	OwnerID uint
+	Name    string
+}
+
+func newTestRequest(t *testing.T, fake *fakeDriver) *corecontainer.Request {
+	sqlDB := sql.OpenDB(fake)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corecontainer.Request{DBM: corecontainer.NewCoreDBManager(db)}
+}
+
+func ownerScope(r corecontainer.RequestInf, db *gorm.DB) *gorm.DB {
+	return db.Where("owner_id = ?", 1)
+}
+
+func TestScopedUpdateWithSameValues(t *testing.T) {
+	repo := NewCoreGormRepository().WithRowScope(ownerScope)
+	r := newTestRequest(t, &fakeDriver{matchingRows: 1})
+	if _, err := repo.Update(r, &scopedModel{ID: 1}, map[string]interface{}{"name": "same"}); err != nil {
+		t.Errorf("update of an allowed row affecting no row returned %v", err)
+	}
+}
+
+func TestScopedUpdateDenied(t *testing.T) {
+	repo := NewCoreGormRepository().WithRowScope(ownerScope)
+	r := newTestRequest(t, &fakeDriver{matchingRows: 0})
+	_, err := repo.UpdateWhere(r, &scopedModel{}, map[string]interface{}{"name": "other"}, "id = ?", 2)
+	if ryerr.GetType(err) != ryerr.Forbidden {
+		t.Errorf("update of a denied row returned %v, expected a Forbidden error", err)
+	}
+}
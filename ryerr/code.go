@@ -0,0 +1,117 @@
+package ryerr
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+)
+
+// Code is a stable, machine-readable application error code (e.g. USER_EMAIL_TAKEN or 42201)
+type Code struct {
+	// Code is returned to clients in ErrorResponse.ErrorCode
+	Code string
+	// Status is the HTTP status code used when responding
+	Status int
+	// Message is used when the error is created without a message
+	Message string
+	// Reportable tells if errors with this code are sent to reporters
+	Reportable bool
+}
+
+var (
+	codesRWLock sync.RWMutex
+	codes       = map[string]Code{}
+)
+
+// RegisterCode registers an application error code. Registering an existing code replaces it
+func RegisterCode(code string, status int, message string, reportable bool) Code {
+	c := Code{Code: code, Status: status, Message: message, Reportable: reportable}
+	codesRWLock.Lock()
+	codes[code] = c
+	codesRWLock.Unlock()
+
+	return c
+}
+
+// GetRegisteredCode returns a registered code
+func GetRegisteredCode(code string) (Code, bool) {
+	codesRWLock.RLock()
+	defer codesRWLock.RUnlock()
+	c, ok := codes[code]
+	return c, ok
+}
+
+// GetRegisteredCodes returns every registered code
+func GetRegisteredCodes() []Code {
+	codesRWLock.RLock()
+	defer codesRWLock.RUnlock()
+	res := make([]Code, 0, len(codes))
+	for _, c := range codes {
+		res = append(res, c)
+	}
+	return res
+}
+
+// ErrorType returns the ErrorType matching the code HTTP status
+func (code Code) ErrorType() ErrorType {
+	if code.Status == 0 {
+		return NoType
+	}
+	return ErrorType(code.Status)
+}
+
+// New creates a new Err with this code. The code default message is used when msg is empty
+func (code Code) New(msg string) error {
+	if msg == "" {
+		msg = code.Message
+	}
+	customErr := Err{errorType: code.ErrorType(), code: &code, originalError: stderrors.New(msg), stackTrace: []string{msg}, callers: callers(2), template: msg, state: &errState{}}
+	customErr.handleOnNew(context.Background())
+
+	return customErr
+}
+
+// Newf creates a new Err with this code and formatted message
+func (code Code) Newf(msg string, args ...interface{}) error {
+	customErr := Err{errorType: code.ErrorType(), code: &code, originalError: fmt.Errorf(msg, args...), stackTrace: []string{msg}, callers: callers(2), template: msg, state: &errState{}}
+	customErr.handleOnNew(context.Background())
+
+	return customErr
+}
+
+// Wrap creates a new wrapped error with this code
+func (code Code) Wrap(err error, msg string) error {
+	return code.Wrapf(err, "%s", msg)
+}
+
+// Wrapf creates a new wrapped error with this code and formatted message
+func (code Code) Wrapf(err error, msg string, args ...interface{}) error {
+	return WithCode(Wrapf(err, msg, args...), code)
+}
+
+// WithCode attaches a code to an error. The error type follows the code HTTP status
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+	if customErr, ok := asErr(err); ok {
+		return Err{errorType: code.ErrorType(), code: &code, originalError: err, contexts: customErr.contexts, stackTrace: customErr.stackTrace, callers: customErr.callers, template: customErr.template, state: stateOf(err)}
+	}
+
+	return Err{errorType: code.ErrorType(), code: &code, originalError: err, callers: callers(2), state: &errState{}}
+}
+
+// GetCode returns the code of the first Err in the error chain
+func GetCode(err error) (Code, bool) {
+	if customErr, ok := asErr(err); ok && customErr.code != nil {
+		return *customErr.code, true
+	}
+	return Code{}, false
+}
+
+// IsCode Check if error has the specified code
+func IsCode(err error, code string) bool {
+	c, ok := GetCode(err)
+	return ok && c.Code == code
+}
@@ -0,0 +1,77 @@
+package ryerr
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// SQLSTATE codes (PostgreSQL) and error numbers (MySQL) of constraint violations and transaction failures
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+
+	mysqlDuplicateEntry  = 1062
+	mysqlRowIsReferenced = 1451
+	mysqlNoReferencedRow = 1452
+	mysqlDeadlock        = 1213
+)
+
+// SQLState returns the SQLSTATE code of a PostgreSQL error in the chain, or an empty string
+func SQLState(err error) string {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState()
+	}
+	return ""
+}
+
+// MySQLErrorNumber returns the error number of a MySQL error in the chain, or 0
+func MySQLErrorNumber(err error) uint16 {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number
+	}
+	return 0
+}
+
+// IsDuplicateKey Check if the error is a unique constraint violation
+func IsDuplicateKey(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey) || SQLState(err) == pgUniqueViolation || MySQLErrorNumber(err) == mysqlDuplicateEntry
+}
+
+// IsForeignKeyViolation Check if the error is a foreign key constraint violation
+func IsForeignKeyViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) || SQLState(err) == pgForeignKeyViolation {
+		return true
+	}
+	number := MySQLErrorNumber(err)
+	return number == mysqlNoReferencedRow || number == mysqlRowIsReferenced
+}
+
+// IsSerializationFailure Check if the error is a serialization failure of a SERIALIZABLE or REPEATABLE READ transaction
+func IsSerializationFailure(err error) bool {
+	return err != nil && SQLState(err) == pgSerializationFailure
+}
+
+// IsDeadlock Check if the error is a deadlock detected by the database
+func IsDeadlock(err error) bool {
+	if err == nil {
+		return false
+	}
+	return SQLState(err) == pgDeadlockDetected || MySQLErrorNumber(err) == mysqlDeadlock
+}
+
+// IsRetryableTransaction Check if the transaction failed because of a concurrent one, and succeeds if run again
+func IsRetryableTransaction(err error) bool {
+	return IsSerializationFailure(err) || IsDeadlock(err)
+}
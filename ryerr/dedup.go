@@ -0,0 +1,227 @@
+package ryerr
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// templateOf returns the message template of the first Err in the chain, or fallback if there is none
+func templateOf(err error, fallback string) string {
+	if customErr, ok := asErr(err); ok && customErr.template != "" {
+		return customErr.template
+	}
+	return fallback
+}
+
+// Fingerprint groups the reports of an error by message template and call site.
+// Two errors created with the same format string at the same line share the fingerprint, whatever the arguments
+func (c Err) Fingerprint() string {
+	key := c.template
+	if key == "" {
+		key = c.Error()
+	}
+	if len(c.callers) > 0 {
+		if f := runtime.FuncForPC(c.callers[0] - 1); f != nil {
+			file, line := f.FileLine(c.callers[0] - 1)
+			key += fmt.Sprintf("|%s:%d@%s", file, line, f.Name())
+		}
+	}
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Summary describes how many times the event occurred since its group was last reported, e.g. "x37 in the last 5m"
+func (e *Event) Summary() string {
+	if e.Occurrences <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("x%d in the last %s", e.Occurrences, formatWithin(e.Within))
+}
+
+// formatWithin formats the duration to the second without the zero units, e.g. "5m", "2m30s" or "1h5m"
+func formatWithin(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Second {
+		return "1s"
+	}
+	var b strings.Builder
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&b, "%dh", h)
+	}
+	if m := d % time.Hour / time.Minute; m > 0 {
+		fmt.Fprintf(&b, "%dm", m)
+	}
+	if sec := d % time.Minute / time.Second; sec > 0 {
+		fmt.Fprintf(&b, "%ds", sec)
+	}
+	return b.String()
+}
+
+// DedupOptions configures the grouping of repeated reports
+type DedupOptions struct {
+	// Window is the period in which repeats of a group are suppressed
+	Window time.Duration
+	// MaxPerWindow is the number of reports sent per group in a window. Default to 1
+	MaxPerWindow int
+}
+
+func DefaultDedupOptions() DedupOptions {
+	return DedupOptions{
+		Window:       5 * time.Minute,
+		MaxPerWindow: 1,
+	}
+}
+
+type dedupGroup struct {
+	windowStart time.Time
+	sent        int
+	suppressed  int
+	lastEvent   Event
+}
+
+// DedupReporter wraps a reporter and suppresses the repeats of an error group (see Err.Fingerprint) within a window.
+// The next report of the group carries the number of suppressed occurrences in Event.Occurrences, and the groups are
+// flushed when their window is over (see Flush). Each group has its own counters, so a flood of one error doesn't
+// affect the others
+type DedupReporter struct {
+	reporter Reporter
+	options  DedupOptions
+	lock     sync.Mutex
+	groups   map[string]*dedupGroup
+	// flushTimer flushes the groups when the earliest window is over. Nil when there is no group
+	flushTimer *time.Timer
+}
+
+// Dedup wraps a reporter with fingerprint based deduplication
+func Dedup(reporter Reporter, options DedupOptions) *DedupReporter {
+	if options.MaxPerWindow <= 0 {
+		options.MaxPerWindow = 1
+	}
+	return &DedupReporter{
+		reporter: reporter,
+		options:  options,
+		groups:   map[string]*dedupGroup{},
+	}
+}
+
+// Name returns the name of the wrapped reporter, so it replaces it in the registry
+func (d *DedupReporter) Name() string {
+	return d.reporter.Name()
+}
+
+// Reporter returns the wrapped reporter
+func (d *DedupReporter) Reporter() Reporter {
+	return d.reporter
+}
+
+// Report reports a copy of the event, the event itself is shared with the other reporters
+func (d *DedupReporter) Report(event *Event) {
+	if d.options.Window <= 0 {
+		d.reporter.Report(event)
+		return
+	}
+	fingerprint := event.Fingerprint
+	if fingerprint == "" {
+		fingerprint = event.Err.Fingerprint()
+	}
+
+	now := time.Now()
+	d.lock.Lock()
+	defer d.scheduleFlush()
+	group, ok := d.groups[fingerprint]
+	if !ok || now.Sub(group.windowStart) >= d.options.Window {
+		occurrences := 1
+		within := d.options.Window
+		if ok {
+			occurrences += group.suppressed
+			within = now.Sub(group.windowStart)
+		}
+		d.groups[fingerprint] = &dedupGroup{windowStart: now, sent: 1, lastEvent: *event}
+		d.lock.Unlock()
+
+		dedupEvent := *event
+		dedupEvent.Occurrences = occurrences
+		dedupEvent.Within = within
+		d.reporter.Report(&dedupEvent)
+		return
+	}
+	if group.sent < d.options.MaxPerWindow {
+		group.sent++
+		group.lastEvent = *event
+		d.lock.Unlock()
+
+		dedupEvent := *event
+		d.reporter.Report(&dedupEvent)
+		return
+	}
+	group.suppressed++
+	group.lastEvent = *event
+	d.lock.Unlock()
+}
+
+// Flush reports a summary of the groups whose window is over and which have suppressed occurrences,
+// and forgets them. It runs when the earliest window is over, call it on shutdown so the last burst is not lost
+func (d *DedupReporter) Flush() {
+	defer d.scheduleFlush()
+	now := time.Now()
+	var pending []Event
+	d.lock.Lock()
+	for fingerprint, group := range d.groups {
+		if now.Sub(group.windowStart) < d.options.Window {
+			continue
+		}
+		if group.suppressed > 0 {
+			event := group.lastEvent
+			event.Occurrences = group.suppressed
+			event.Within = now.Sub(group.windowStart)
+			pending = append(pending, event)
+		}
+		delete(d.groups, fingerprint)
+	}
+	d.lock.Unlock()
+
+	for i := range pending {
+		d.reporter.Report(&pending[i])
+	}
+}
+
+// scheduleFlush arms the flush timer for the earliest window to be over, if there is none armed
+func (d *DedupReporter) scheduleFlush() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.flushTimer != nil || len(d.groups) == 0 {
+		return
+	}
+	var earliest time.Time
+	for _, group := range d.groups {
+		if earliest.IsZero() || group.windowStart.Before(earliest) {
+			earliest = group.windowStart
+		}
+	}
+	d.flushTimer = time.AfterFunc(time.Until(earliest.Add(d.options.Window)), func() {
+		d.lock.Lock()
+		d.flushTimer = nil
+		d.lock.Unlock()
+		d.Flush()
+	})
+}
+
+func (d *DedupReporter) SetExtra(key string, value interface{}) {
+	if extraSetter, ok := d.reporter.(ExtraSetter); ok {
+		extraSetter.SetExtra(key, value)
+	}
+}
+
+// FlushDedup flushes every registered DedupReporter
+func FlushDedup() {
+	for _, r := range GetReporters() {
+		if d, ok := r.(*DedupReporter); ok {
+			d.Flush()
+		}
+	}
+}
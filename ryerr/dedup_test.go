@@ -0,0 +1,69 @@
+package ryerr
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingReporter struct {
+	lock   sync.Mutex
+	events []Event
+}
+
+func (r *recordingReporter) Name() string { return "recording" }
+
+func (r *recordingReporter) Report(event *Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, *event)
+}
+
+func (r *recordingReporter) reported() []Event {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func TestEventSummary(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:                  "x2 in the last 30s",
+		5 * time.Minute:                   "x2 in the last 5m",
+		2*time.Minute + 30*time.Second:    "x2 in the last 2m30s",
+		time.Hour + 5*time.Minute:         "x2 in the last 1h5m",
+		10*time.Second + time.Millisecond: "x2 in the last 10s",
+	}
+	for within, want := range tests {
+		event := Event{Occurrences: 2, Within: within}
+		if got := event.Summary(); got != want {
+			t.Errorf("Summary() within %s = %q, want %q", within, got, want)
+		}
+	}
+}
+
+func TestDedupReporterFlushesAfterWindow(t *testing.T) {
+	recorder := &recordingReporter{}
+	dedup := Dedup(recorder, DedupOptions{Window: 50 * time.Millisecond})
+	event := &Event{Fingerprint: "group"}
+	for range 3 {
+		dedup.Report(event)
+	}
+	if event.Occurrences != 0 {
+		t.Fatalf("the shared event was mutated: Occurrences = %d", event.Occurrences)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(recorder.reported()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	events := recorder.reported()
+	if len(events) != 2 || events[1].Occurrences != 2 {
+		t.Fatalf("reported %+v, want the first event then a summary of 2 occurrences", events)
+	}
+	dedup.lock.Lock()
+	groups := len(dedup.groups)
+	dedup.lock.Unlock()
+	if groups != 0 {
+		t.Fatalf("%d groups left after the flush, want 0", groups)
+	}
+}
@@ -1,17 +1,16 @@
 package ryerr
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
-	"github.com/gin-gonic/gin"
-	"io"
+	"net/http"
 	"runtime"
 	"strconv"
-	"time"
 
-	"github.com/getsentry/sentry-go"
 	"github.com/pkg/errors"
 	loghelper "github.com/rayyone/go-core/helpers/log"
-	ry_slack "github.com/rayyone/go-core/helpers/slack"
+	"github.com/rayyone/go-core/helpers/requestid"
 	"gorm.io/gorm"
 )
 
@@ -20,100 +19,156 @@ type ErrorType uint
 
 // NoType error
 const (
-	NoType              ErrorType = 500
-	InternalServer      ErrorType = 500
-	BadRequest          ErrorType = 400
-	Unauthorized        ErrorType = 401
-	Forbidden           ErrorType = 403
-	NotFound            ErrorType = 404
-	Validation          ErrorType = 422
-	UnprocessableEntity ErrorType = 422
-	TooManyRequests     ErrorType = 429
+	NoType                ErrorType = 500
+	InternalServer        ErrorType = 500
+	BadRequest            ErrorType = 400
+	Unauthorized          ErrorType = 401
+	Forbidden             ErrorType = 403
+	NotFound              ErrorType = 404
+	Conflict              ErrorType = 409
+	RequestEntityTooLarge ErrorType = 413
+	Validation            ErrorType = 422
+	UnprocessableEntity   ErrorType = 422
+	TooManyRequests       ErrorType = 429
+	ServiceUnavailable    ErrorType = 503
+	GatewayTimeout        ErrorType = 504
 )
 
 type Err struct {
 	errorType     ErrorType
+	code          *Code
 	originalError error
-	contexts      []errorContext
+	contexts      []FieldError
 	stackTrace    []string
-	report        bool
+	callers       []uintptr
+	template      string
+	report        reportPolicy
+	state         *errState
 }
 
-type errorContext struct {
-	Field   string
-	Message string
+// FieldError is an error context attached to a field. Tag and Param are set for validation errors
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag,omitempty"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
 }
 
-func writeLog(msg string, args ...interface{}) {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	fullMsg := fmt.Sprintf("[%s] %s", timestamp, msg)
-	errLogger := gin.DefaultErrorWriter
-	for _, arg := range args {
-		if w, ok := arg.(io.Writer); ok {
-			if w == gin.DefaultWriter || w == gin.DefaultErrorWriter {
-				errLogger = w
-				break
-			}
-		}
-	}
-	errLogger.Write([]byte(fullMsg + "\n"))
-}
+var logger = loghelper.Named("ryerr")
 
 func (c Err) Error() string {
+	if c.originalError == nil {
+		return ""
+	}
 	return c.originalError.Error()
 }
 
-// New creates a new Err
-func (errorType ErrorType) New(msg string) error {
-	loghelper.PrintRed(msg)
-	shouldReport := shouldReport(errorType)
+// Unwrap returns the wrapped error so Err takes part in errors.Is / errors.As chains
+func (c Err) Unwrap() error {
+	return c.originalError
+}
+
+// Is reports whether target has the same ErrorType. Target can be an ErrorType, an Err or an *Err:
+//
+//	errors.Is(err, ryerr.NotFound)
+func (c Err) Is(target error) bool {
+	switch t := target.(type) {
+	case ErrorType:
+		return c.errorType == t
+	case Err:
+		return c.errorType == t.errorType
+	case *Err:
+		return t != nil && c.errorType == t.errorType
+	}
+	return false
+}
 
-	writeLog(msg)
+// As allows errors.As to target an *Err as well as an Err
+func (c Err) As(target interface{}) bool {
+	if t, ok := target.(**Err); ok {
+		customErr := c
+		*t = &customErr
+		return true
+	}
+	return false
+}
 
-	customErr := Err{errorType: errorType, originalError: errors.New(msg), stackTrace: []string{msg}}
-	if shouldReport {
-		customErr.Report()
+// Error makes ErrorType usable as a target of errors.Is
+func (errorType ErrorType) Error() string {
+	if text := http.StatusText(int(errorType)); text != "" {
+		return text
 	}
+	return strconv.Itoa(int(errorType))
+}
+
+// withMessage replaces the message of an error while keeping it in the chain
+type withMessage struct {
+	msg   string
+	cause error
+}
+
+func (w *withMessage) Error() string {
+	return w.msg
+}
+
+func (w *withMessage) Unwrap() error {
+	return w.cause
+}
+
+// asErr finds the first Err (or *Err) in the error chain
+func asErr(err error) (Err, bool) {
+	if err == nil {
+		return Err{}, false
+	}
+	var customErr Err
+	if errors.As(err, &customErr) {
+		return customErr, true
+	}
+	var customErrPtr *Err
+	if errors.As(err, &customErrPtr) && customErrPtr != nil {
+		return *customErrPtr, true
+	}
+	return Err{}, false
+}
+
+// New creates a new Err
+func (errorType ErrorType) New(msg string) error {
+	return errorType.NewCtx(context.Background(), msg)
+}
+
+// NewCtx creates a new Err and reports it with the scope held in the context
+func (errorType ErrorType) NewCtx(ctx context.Context, msg string) error {
+	customErr := Err{errorType: errorType, originalError: stderrors.New(msg), stackTrace: []string{msg}, callers: callers(2), template: msg, state: &errState{}}
+	customErr.handleOnNew(ctx)
 
 	return customErr
 }
 
 // NewAndReport creates a new Err and report
 func (errorType ErrorType) NewAndReport(msg string) error {
-	loghelper.PrintRed(msg)
-
-	writeLog(msg)
-	customErr := Err{errorType: errorType, originalError: errors.New(msg), stackTrace: []string{msg}}
-	customErr.Report()
+	customErr := Err{errorType: errorType, originalError: stderrors.New(msg), stackTrace: []string{msg}, callers: callers(2), template: msg, report: reportAlways, state: &errState{}}
+	customErr.handleOnNew(context.Background())
 
 	return customErr
 }
 
 // Newf creates a new Err with formatted message
 func (errorType ErrorType) Newf(msg string, args ...interface{}) error {
-	out := fmt.Sprintf(msg, args...)
-	loghelper.PrintRed(out)
-	shouldReport := shouldReport(errorType)
+	return errorType.NewfCtx(context.Background(), msg, args...)
+}
 
-	writeLog(out)
-
-	customErr := Err{errorType: errorType, originalError: fmt.Errorf(msg, args...), stackTrace: []string{msg}}
-	if shouldReport {
-		customErr.Report()
-	}
+// NewfCtx creates a new Err with formatted message and reports it with the scope held in the context
+func (errorType ErrorType) NewfCtx(ctx context.Context, msg string, args ...interface{}) error {
+	customErr := Err{errorType: errorType, originalError: fmt.Errorf(msg, args...), stackTrace: []string{msg}, callers: callers(2), template: msg, state: &errState{}}
+	customErr.handleOnNew(ctx)
 
 	return customErr
 }
 
 // NewfAndReport creates a new Err with formatted message and report
 func (errorType ErrorType) NewfAndReport(msg string, args ...interface{}) error {
-	out := fmt.Sprintf(msg, args...)
-	loghelper.PrintRed(out)
-
-	writeLog(out)
-
-	customErr := Err{errorType: errorType, originalError: fmt.Errorf(msg, args...), stackTrace: []string{msg}}
-	customErr.Report()
+	customErr := Err{errorType: errorType, originalError: fmt.Errorf(msg, args...), stackTrace: []string{msg}, callers: callers(2), template: msg, report: reportAlways, state: &errState{}}
+	customErr.handleOnNew(context.Background())
 
 	return customErr
 }
@@ -126,7 +181,7 @@ func shouldReport(errorType ErrorType) bool {
 	}
 
 	switch ErrorType(statusCode) {
-	case Unauthorized, NotFound, UnprocessableEntity, TooManyRequests:
+	case Unauthorized, Forbidden, NotFound, Conflict, RequestEntityTooLarge, UnprocessableEntity, TooManyRequests:
 		return false
 	default:
 		return true
@@ -135,142 +190,148 @@ func shouldReport(errorType ErrorType) bool {
 
 // Wrap creates a new wrapped error
 func (errorType ErrorType) Wrap(err error, msg string) error {
-	return errorType.Wrapf(err, msg)
+	return errorType.Wrapf(err, "%s", msg)
 }
 
 // Wrapf creates a new wrapped error with formatted message
 func (errorType ErrorType) Wrapf(err error, msg string, args ...interface{}) error {
-	return Err{errorType: errorType, originalError: errors.Wrapf(err, msg, args...)}
+	return Err{errorType: errorType, originalError: errors.WithMessagef(err, msg, args...), callers: callersOf(err), template: templateOf(err, msg), state: stateOf(err)}
 }
 
+// Report sends the error to every registered reporter
 func (c Err) Report() {
-	var fullText = "========== Error Stack Strace =========="
-	loghelper.PrintRed(fullText)
-	fullText = "\n" + fullText + "\n"
-	var stackTrace []string
-	for i := 4; i < 9; i++ { // Skip 4 function, Get last 5 error trace
-		file, line, fnName := traceCaller(i)
-		traceMsg := fmt.Sprintf("%s:%d@%s", file, line, fnName)
-		loghelper.PrintYellow(traceMsg)
-		stackTrace = append(stackTrace, traceMsg)
-		fullText += traceMsg + "\n"
-	}
-
-	writeLog(fullText)
-
-	sentry.ConfigureScope(func(scope *sentry.Scope) {
-		scope.SetExtra("stack_trace", stackTrace)
+	c.ReportCtx(context.Background())
+}
 
-	})
-	eventId := sentry.CaptureException(c)
-	slackMsg := fmt.Sprintf("*%s*\n", c.Error())
-	if eventId != nil {
-		slackMsg += fmt.Sprintf("*EventID:* %s\n", *eventId)
-		if sentryProjectUrl := ry_slack.CurrentSlackClient().GetOption("sentry_project_url"); sentryProjectUrl != nil {
-			slackMsg += fmt.Sprintf("<%s?query=%s|See more detail>", sentryProjectUrl, *eventId)
-		}
+// ReportCtx sends the error to every registered reporter with the scope held in the context
+func (c Err) ReportCtx(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	ignoreErrorOption := ry_slack.CurrentSlackClient().GetOption("ignore_error")
-	ignoreError, ok := ignoreErrorOption.(bool)
-	if ok && ignoreError {
-		return
-	}
-	if errChannel := ry_slack.CurrentSlackClient().GetOption("error_channel"); errChannel != nil {
-		ry_slack.SendSimpleMessageToChannel(errChannel.(string), "ry-api error", slackMsg)
-	} else {
-		ry_slack.SendSimpleMessage("ry-api error", slackMsg)
+	stackTrace := c.stackTraceLines()
+	if len(stackTrace) == 0 {
+		stackTrace = Err{callers: callers(2)}.stackTraceLines()
 	}
+
+	dispatch(&Event{Err: c, StackTrace: stackTrace, Ctx: ctx, RequestID: requestid.FromContext(ctx), Scope: ScopeFromContext(ctx)})
 }
 
 // New creates a no type error and report to sentry
 func New(msg string) error {
-	loghelper.PrintRed(msg)
-
-	writeLog(msg)
-	err := Err{errorType: NoType, originalError: errors.New(msg)}
+	return NewCtx(context.Background(), msg)
+}
 
-	err.Report()
+// NewCtx creates a no type error and reports it with the scope held in the context
+func NewCtx(ctx context.Context, msg string) error {
+	err := Err{errorType: NoType, originalError: stderrors.New(msg), callers: callers(2), template: msg, report: reportAlways, state: &errState{}}
+	err.handleOnNew(ctx)
 
 	return err
 }
 
 // NewAndDontReport creates a new Err and don't report it
 func NewAndDontReport(msg string) error {
-	loghelper.PrintRed(msg)
-
-	writeLog(msg)
-	err := Err{errorType: NoType, originalError: errors.New(msg)}
+	err := Err{errorType: NoType, originalError: stderrors.New(msg), callers: callers(2), template: msg, report: reportNever, state: &errState{}}
+	err.handleOnNew(context.Background())
 
 	return err
 }
 
 // Newf creates a no type error with formatted message
 func Newf(msg string, args ...interface{}) error {
-	out := fmt.Sprintf(msg, args...)
-	loghelper.PrintRed(out)
-
-	writeLog(out)
-	err := Err{errorType: NoType, originalError: errors.New(out)}
+	return NewfCtx(context.Background(), msg, args...)
+}
 
-	err.Report()
+// NewfCtx creates a no type error with formatted message and reports it with the scope held in the context
+func NewfCtx(ctx context.Context, msg string, args ...interface{}) error {
+	out := fmt.Sprintf(msg, args...)
+	err := Err{errorType: NoType, originalError: stderrors.New(out), callers: callers(2), template: msg, report: reportAlways, state: &errState{}}
+	err.handleOnNew(ctx)
 
 	return err
 }
 
+// Msg replaces the error message. The previous error is kept in the chain
 func Msg(err error, msg string) error {
 	fileName, line, fnName := traceCaller(3)
 	errorMsg := fmt.Sprintf("%s:%d@%s()", fileName, line, fnName)
-	if customErr, ok := err.(Err); ok {
+	if customErr, ok := asErr(err); ok {
 		return Err{
 			errorType:     customErr.errorType,
-			originalError: errors.New(msg),
+			code:          customErr.code,
+			originalError: &withMessage{msg: msg, cause: err},
 			contexts:      customErr.contexts,
 			stackTrace:    append([]string{errorMsg}, customErr.stackTrace...),
+			callers:       callersOf(err),
+			template:      templateOf(err, msg),
+			report:        customErr.report,
+			state:         stateOf(err),
 		}
 	}
 
-	return Err{errorType: NoType, originalError: errors.New(msg), stackTrace: []string{errorMsg}}
+	return Err{errorType: NoType, originalError: &withMessage{msg: msg, cause: err}, stackTrace: []string{errorMsg}, callers: callers(2), template: msg, state: &errState{}}
 }
 
 // Wrap an error with a string
 func Wrap(err error, msg string) error {
-	return Wrapf(err, msg)
+	return Wrapf(err, "%s", msg)
 }
 
 // Wrapf an error with format string
 func Wrapf(err error, msg string, args ...interface{}) error {
-	wrappedError := errors.Wrapf(err, msg, args...)
-	if customErr, ok := err.(Err); ok {
+	wrappedError := errors.WithMessagef(err, msg, args...)
+	if customErr, ok := asErr(err); ok {
 		return Err{
 			errorType:     customErr.errorType,
+			code:          customErr.code,
 			originalError: wrappedError,
 			contexts:      customErr.contexts,
 			stackTrace:    customErr.stackTrace,
+			callers:       callersOf(err),
+			template:      templateOf(err, msg),
+			report:        customErr.report,
+			state:         stateOf(err),
 		}
 	}
 
-	return Err{errorType: NoType, originalError: wrappedError}
+	return Err{errorType: NoType, originalError: wrappedError, callers: callers(2), template: msg, state: &errState{}}
 }
 
-// Cause gives the original error
+// NewWrapf creates a no type error with formatted message like Newf, keeping err in the chain (e.g. for errors.As),
+// and handles it like Newf in HandleOnNew mode
+func NewWrapf(err error, msg string, args ...interface{}) error {
+	customErr := Wrapf(err, msg, args...).(Err)
+	if _, ok := asErr(err); !ok {
+		customErr.callers = callers(2)
+		customErr.report = reportAlways
+	}
+	customErr.handleOnNew(context.Background())
+
+	return customErr
+}
+
+// Cause gives the first Err found in the error chain, or the root cause if there is none
 func Cause(err error) error {
-	return errors.Cause(err).(Err)
+	if customErr, ok := asErr(err); ok {
+		return customErr
+	}
+	return errors.Cause(err)
 }
 
 // AddStackTrace an error with format string
 func AddStackTrace(err error, msg string) error {
-	if customErr, ok := err.(Err); ok {
+	if customErr, ok := asErr(err); ok {
 		stackTrace := append([]string{msg}, customErr.stackTrace...)
-		return Err{errorType: customErr.errorType, originalError: customErr.originalError, contexts: customErr.contexts, stackTrace: stackTrace}
+		return Err{errorType: customErr.errorType, code: customErr.code, originalError: err, contexts: customErr.contexts, stackTrace: stackTrace, callers: callersOf(err), template: templateOf(err, ""), report: customErr.report, state: stateOf(err)}
 	}
 
 	stackTrace := []string{msg}
-	return Err{errorType: NoType, originalError: err, stackTrace: stackTrace}
+	return Err{errorType: NoType, originalError: err, stackTrace: stackTrace, callers: callers(2), state: &errState{}}
 }
 
-// GetStackTrace returns the error stack trace
+// GetStackTrace returns the stack trace of the first Err in the error chain
 func GetStackTrace(err error) []string {
-	if customErr, ok := err.(Err); ok {
+	if customErr, ok := asErr(err); ok {
 		return customErr.stackTrace
 	}
 	return []string{}
@@ -278,20 +339,25 @@ func GetStackTrace(err error) []string {
 
 // AddErrorContext adds a context to an error
 func AddErrorContext(err error, field string, message string) error {
-	context := errorContext{Field: field, Message: message}
-	if customErr, ok := err.(Err); ok {
-		contexts := append(customErr.contexts, context)
-		return Err{errorType: customErr.errorType, originalError: customErr.originalError, contexts: contexts, stackTrace: customErr.stackTrace}
+	return AddFieldError(err, FieldError{Field: field, Message: message})
+}
+
+// AddFieldError adds a field error to an error. Field errors are kept in the order they are added
+func AddFieldError(err error, fieldError FieldError) error {
+	if customErr, ok := asErr(err); ok {
+		contexts := append(customErr.contexts[:len(customErr.contexts):len(customErr.contexts)], fieldError)
+		return Err{errorType: customErr.errorType, code: customErr.code, originalError: err, contexts: contexts, stackTrace: customErr.stackTrace, callers: callersOf(err), template: templateOf(err, ""), report: customErr.report, state: stateOf(err)}
 	}
 
-	contexts := []errorContext{context}
-	return Err{errorType: NoType, originalError: err, contexts: contexts}
+	contexts := []FieldError{fieldError}
+	return Err{errorType: NoType, originalError: err, contexts: contexts, callers: callers(2), state: &errState{}}
 }
 
-// GetErrorContexts returns the error context
+// GetErrorContexts returns the contexts of the first Err in the error chain.
+// When a field has several contexts, the last one added is returned, see GetFieldErrors to get all of them
 func GetErrorContexts(err error) map[string]string {
 	res := make(map[string]string)
-	if customErr, ok := err.(Err); ok {
+	if customErr, ok := asErr(err); ok {
 		for _, context := range customErr.contexts {
 			res[context.Field] = context.Message
 		}
@@ -299,9 +365,19 @@ func GetErrorContexts(err error) map[string]string {
 	return res
 }
 
-// GetType returns the error type
+// GetFieldErrors returns the field errors of the first Err in the error chain, in the order they were added
+func GetFieldErrors(err error) []FieldError {
+	if customErr, ok := asErr(err); ok {
+		res := make([]FieldError, len(customErr.contexts))
+		copy(res, customErr.contexts)
+		return res
+	}
+	return []FieldError{}
+}
+
+// GetType returns the type of the first Err in the error chain
 func GetType(err error) ErrorType {
-	if customErr, ok := err.(Err); ok {
+	if customErr, ok := asErr(err); ok {
 		return customErr.errorType
 	}
 
@@ -314,12 +390,7 @@ func Is(err error, errorType ErrorType) bool {
 		return false
 	}
 
-	errType := NoType
-	if customErr, ok := err.(Err); ok {
-		errType = customErr.errorType
-	}
-
-	return errType == errorType
+	return GetType(err) == errorType
 }
 
 // IsNotFound Check if error is NotFound error type
@@ -335,11 +406,13 @@ func IsGormError(err error) bool {
 	return err != nil && !IsRecordNotFound(err)
 }
 
+// SetExtra attaches extra data to the next reports of every reporter implementing ExtraSetter
 func SetExtra(key string, value interface{}) {
-	//@TODO: Need to export to an interface!
-	sentry.ConfigureScope(func(scope *sentry.Scope) {
-		scope.SetExtra(key, value)
-	})
+	for _, r := range GetReporters() {
+		if extraSetter, ok := r.(ExtraSetter); ok {
+			extraSetter.SetExtra(key, value)
+		}
+	}
 }
 
 func traceCaller(skip int) (file string, line int, fnName string) {
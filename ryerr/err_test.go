@@ -0,0 +1,42 @@
+package ryerr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewWrapfKeepsTheChainAndReports(t *testing.T) {
+	reporters := GetReporters()
+	ClearReporters()
+	recorder := &recordingReporter{}
+	RegisterReporter(recorder)
+	defer func() {
+		ClearReporters()
+		for _, reporter := range reporters {
+			RegisterReporter(reporter)
+		}
+	}()
+
+	cause := errors.New("deadlock detected")
+	err := NewWrapf(cause, "Base Repo [%s] Error", "Update")
+	if !errors.Is(err, cause) {
+		t.Errorf("the cause is not in the chain of %v", err)
+	}
+	if err.Error() != "Base Repo [Update] Error: deadlock detected" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if len(recorder.reported()) != 1 {
+		t.Errorf("%d events reported, expected the new error", len(recorder.reported()))
+	}
+}
+
+func TestGetErrorContextsKeepsTheLastContext(t *testing.T) {
+	err := AddErrorContext(Validation.New("invalid"), "email", "first")
+	err = AddErrorContext(err, "email", "last")
+	if got := GetErrorContexts(err)["email"]; got != "last" {
+		t.Errorf("GetErrorContexts()[email] = %q, expected the last context", got)
+	}
+	if fieldErrors := GetFieldErrors(err); len(fieldErrors) != 2 {
+		t.Errorf("GetFieldErrors() = %v, expected both contexts", fieldErrors)
+	}
+}
@@ -0,0 +1,120 @@
+package ryerr
+
+import (
+	"context"
+	"sync/atomic"
+
+	loghelper "github.com/rayyone/go-core/helpers/log"
+)
+
+// Mode tells when errors are logged and reported
+type Mode int32
+
+const (
+	// HandleOnNew logs and reports errors the moment they are created. This is the default
+	HandleOnNew Mode = iota
+	// HandleAtBoundary keeps errors as pure values. They are logged and reported once,
+	// at a boundary: the HandleError middleware, a job runner or an explicit Handle call
+	HandleAtBoundary
+)
+
+var mode atomic.Int32
+
+// SetMode sets when errors are logged and reported
+func SetMode(m Mode) {
+	mode.Store(int32(m))
+}
+
+// GetMode returns when errors are logged and reported
+func GetMode() Mode {
+	return Mode(mode.Load())
+}
+
+type reportPolicy uint8
+
+const (
+	reportByType reportPolicy = iota
+	reportAlways
+	reportNever
+)
+
+// errState is shared by an Err and every error wrapping it, so an error chain is handled only once
+type errState struct {
+	handled atomic.Bool
+}
+
+// markHandled returns false if the error has already been handled
+func (s *errState) markHandled() bool {
+	if s == nil {
+		return true
+	}
+	return s.handled.CompareAndSwap(false, true)
+}
+
+// stateOf returns the state of the first Err in the chain, or a new one if there is none
+func stateOf(err error) *errState {
+	if customErr, ok := asErr(err); ok && customErr.state != nil {
+		return customErr.state
+	}
+	return &errState{}
+}
+
+func (c Err) shouldReport() bool {
+	switch c.report {
+	case reportAlways:
+		return true
+	case reportNever:
+		return false
+	}
+	if c.code != nil {
+		return c.code.Reportable
+	}
+	return shouldReport(c.errorType)
+}
+
+// handleOnNew logs and reports a new error when the mode is HandleOnNew
+func (c Err) handleOnNew(ctx context.Context) {
+	if GetMode() == HandleAtBoundary {
+		return
+	}
+	c.handle(ctx, c.Error())
+}
+
+func (c Err) handle(ctx context.Context, msg string) {
+	if !c.state.markHandled() {
+		return
+	}
+	attrs := []any{"type", uint(c.errorType)}
+	if c.code != nil {
+		attrs = append(attrs, "code", c.code.Code)
+	}
+	loghelper.WithContext(ctx, logger).ErrorContext(ctx, msg, attrs...)
+
+	if c.shouldReport() {
+		c.ReportCtx(ctx)
+	}
+}
+
+// Handle logs the error and reports it if its type is reportable. An error chain is handled only once:
+// errors already handled (e.g. created in HandleOnNew mode, or handled by a previous boundary) are skipped
+func Handle(err error) {
+	HandleCtx(context.Background(), err)
+}
+
+// HandleCtx handles the error and reports it with the scope held in the context (see Handle)
+func HandleCtx(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	customErr, ok := asErr(err)
+	if !ok {
+		customErr = Err{errorType: NoType, originalError: err, callers: callers(2), template: err.Error(), state: &errState{}}
+	}
+	customErr.handle(ctx, err.Error())
+}
+
+// IsHandled Check if the error has already been logged and reported
+func IsHandled(err error) bool {
+	customErr, ok := asErr(err)
+	return ok && customErr.state != nil && customErr.state.handled.Load()
+}
@@ -0,0 +1,111 @@
+package ryerr
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces the values registered as sensitive
+const RedactedValue = "[REDACTED]"
+
+var (
+	sensitiveRWLock sync.RWMutex
+	sensitiveKeys   = map[string]bool{}
+)
+
+// RegisterSensitive registers field keys (case insensitive) whose values are redacted when an error is serialized
+func RegisterSensitive(keys ...string) {
+	sensitiveRWLock.Lock()
+	defer sensitiveRWLock.Unlock()
+	for _, key := range keys {
+		sensitiveKeys[strings.ToLower(key)] = true
+	}
+}
+
+// IsSensitive Check if the key is registered as sensitive
+func IsSensitive(key string) bool {
+	sensitiveRWLock.RLock()
+	defer sensitiveRWLock.RUnlock()
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+func redact(key string, value string) string {
+	if IsSensitive(key) {
+		return RedactedValue
+	}
+	return value
+}
+
+type jsonErr struct {
+	Type       uint         `json:"type"`
+	Code       string       `json:"code,omitempty"`
+	Message    string       `json:"message"`
+	Causes     []string     `json:"causes,omitempty"`
+	Contexts   []FieldError `json:"contexts,omitempty"`
+	StackTrace []string     `json:"stack_trace,omitempty"`
+}
+
+// causeChain returns the messages of the errors wrapped by the Err, from outermost to innermost
+func (c Err) causeChain() []string {
+	var res []string
+	last := c.Error()
+	for err := stderrors.Unwrap(error(c)); err != nil; err = stderrors.Unwrap(err) {
+		// Skip the errors that only carry a stack or a type, they repeat the previous message
+		if msg := err.Error(); msg != last {
+			res = append(res, msg)
+			last = msg
+		}
+	}
+	return res
+}
+
+func (c Err) toJSONErr() jsonErr {
+	res := jsonErr{
+		Type:       uint(c.errorType),
+		Message:    c.Error(),
+		Causes:     c.causeChain(),
+		StackTrace: c.stackTraceLines(),
+	}
+	if c.code != nil {
+		res.Code = c.code.Code
+	}
+	for _, context := range c.contexts {
+		context.Message = redact(context.Field, context.Message)
+		res.Contexts = append(res.Contexts, context)
+	}
+	return res
+}
+
+// MarshalJSON encodes the error type, code, message, wrapped cause chain, contexts and stack trace
+func (c Err) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.toJSONErr())
+}
+
+// LogValue implements slog.LogValuer, so the error is logged as a group of structured attributes
+func (c Err) LogValue() slog.Value {
+	e := c.toJSONErr()
+	attrs := []slog.Attr{
+		slog.Uint64("type", uint64(e.Type)),
+		slog.String("message", e.Message),
+	}
+	if e.Code != "" {
+		attrs = append(attrs, slog.String("code", e.Code))
+	}
+	if len(e.Causes) > 0 {
+		attrs = append(attrs, slog.Any("causes", e.Causes))
+	}
+	if len(e.Contexts) > 0 {
+		contextAttrs := make([]any, 0, len(e.Contexts))
+		for _, context := range e.Contexts {
+			contextAttrs = append(contextAttrs, slog.String(context.Field, context.Message))
+		}
+		attrs = append(attrs, slog.Group("contexts", contextAttrs...))
+	}
+	if len(e.StackTrace) > 0 {
+		attrs = append(attrs, slog.Any("stack_trace", e.StackTrace))
+	}
+	return slog.GroupValue(attrs...)
+}
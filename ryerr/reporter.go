@@ -0,0 +1,107 @@
+package ryerr
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Reporter receives errors that should be reported to an external tracker (Sentry, Slack, logs...)
+type Reporter interface {
+	// Name identifies the reporter in the registry. Registering a reporter with an existing name replaces it
+	Name() string
+	// Report sends the event to the tracker
+	Report(event *Event)
+}
+
+// ExtraSetter is implemented by reporters that can attach extra data to the next reports
+type ExtraSetter interface {
+	SetExtra(key string, value interface{})
+}
+
+// Event is a single error report. It is passed to every registered reporter in registration order,
+// so a reporter can read what a previous one filled in (e.g. the Sentry event ID in the Slack message)
+type Event struct {
+	Err        Err
+	StackTrace []string
+	EventID    string
+	// Ctx is the context the error was reported with
+	Ctx context.Context
+	// RequestID is the ID of the request the error occurred in (see middleware.RequestID). Empty outside of a request
+	RequestID string
+	// Scope holds the extras, tags, user and breadcrumbs of the request. Nil when reported without scope
+	Scope *Scope
+	// Fingerprint groups the reports of the same error (see Err.Fingerprint)
+	Fingerprint string
+	// Occurrences is the number of times the group occurred since it was last reported (see DedupReporter)
+	Occurrences int
+	// Within is the period covered by Occurrences
+	Within time.Duration
+}
+
+var (
+	reportersRWLock sync.RWMutex
+	reporters       []Reporter
+)
+
+func init() {
+	reporters = []Reporter{
+		NewLogReporter(),
+		NewSentryReporter(),
+		Dedup(NewSlackReporter(), DefaultDedupOptions()),
+	}
+}
+
+// RegisterReporter adds a reporter to the registry, replacing any reporter with the same name
+func RegisterReporter(reporter Reporter) {
+	reportersRWLock.Lock()
+	defer reportersRWLock.Unlock()
+	for i, r := range reporters {
+		if r.Name() == reporter.Name() {
+			reporters[i] = reporter
+			return
+		}
+	}
+	reporters = append(reporters, reporter)
+}
+
+// RemoveReporter removes the reporter with the given name from the registry
+func RemoveReporter(name string) {
+	reportersRWLock.Lock()
+	defer reportersRWLock.Unlock()
+	res := make([]Reporter, 0, len(reporters))
+	for _, r := range reporters {
+		if r.Name() != name {
+			res = append(res, r)
+		}
+	}
+	reporters = res
+}
+
+// ClearReporters removes every registered reporter
+func ClearReporters() {
+	reportersRWLock.Lock()
+	defer reportersRWLock.Unlock()
+	reporters = nil
+}
+
+// GetReporters returns the registered reporters in registration order
+func GetReporters() []Reporter {
+	reportersRWLock.RLock()
+	defer reportersRWLock.RUnlock()
+	res := make([]Reporter, len(reporters))
+	copy(res, reporters)
+	return res
+}
+
+func dispatch(event *Event) {
+	if event.Fingerprint == "" {
+		event.Fingerprint = event.Err.Fingerprint()
+	}
+	if event.Occurrences == 0 {
+		event.Occurrences = 1
+	}
+	for _, r := range GetReporters() {
+		r.Report(event)
+	}
+}
@@ -0,0 +1,173 @@
+package ryerr
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/getsentry/sentry-go"
+	loghelper "github.com/rayyone/go-core/helpers/log"
+	ry_slack "github.com/rayyone/go-core/helpers/slack"
+)
+
+// LogReporter logs the error stack trace
+type LogReporter struct{}
+
+func NewLogReporter() *LogReporter {
+	return &LogReporter{}
+}
+
+func (l *LogReporter) Name() string {
+	return "log"
+}
+
+func (l *LogReporter) Report(event *Event) {
+	loghelper.WithContext(event.Ctx, logger).ErrorContext(event.Ctx, "Error stack trace", "error", event.Err.Error(), "stack_trace", event.StackTrace)
+}
+
+// SentryReporter captures the error as a Sentry exception
+type SentryReporter struct{}
+
+func NewSentryReporter() *SentryReporter {
+	return &SentryReporter{}
+}
+
+func (s *SentryReporter) Name() string {
+	return "sentry"
+}
+
+// Report captures the error with the hub held in the event context (see sentry.SetHubOnContext), or the current hub.
+// The event scope is pushed on a new sentry scope, so it doesn't leak into other reports
+func (s *SentryReporter) Report(event *Event) {
+	var hub *sentry.Hub
+	if event.Ctx != nil {
+		hub = sentry.GetHubFromContext(event.Ctx)
+	}
+	if hub == nil {
+		hub = sentry.CurrentHub()
+	}
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetExtra("stack_trace", event.StackTrace)
+		if event.RequestID != "" {
+			scope.SetTag("request_id", event.RequestID)
+		}
+		if event.Scope != nil {
+			scope.SetExtras(event.Scope.Extras())
+			scope.SetTags(event.Scope.Tags())
+			if user := event.Scope.User(); user != nil {
+				scope.SetUser(sentry.User{ID: user.ID, Email: user.Email, Username: user.Username, IPAddress: user.IPAddress})
+			}
+			for _, b := range event.Scope.Breadcrumbs() {
+				scope.AddBreadcrumb(&sentry.Breadcrumb{
+					Category:  b.Category,
+					Message:   b.Message,
+					Level:     sentry.Level(b.Level),
+					Data:      b.Data,
+					Timestamp: b.Timestamp,
+				}, maxBreadcrumbs)
+			}
+		}
+		if eventId := hub.CaptureException(event.Err); eventId != nil {
+			event.EventID = string(*eventId)
+		}
+	})
+}
+
+func (s *SentryReporter) SetExtra(key string, value interface{}) {
+	sentry.ConfigureScope(func(scope *sentry.Scope) {
+		scope.SetExtra(key, value)
+	})
+}
+
+// SlackReporter posts the error to the Slack error channel. The Slack daily budget is counted per error group
+type SlackReporter struct{}
+
+func NewSlackReporter() *SlackReporter {
+	return &SlackReporter{}
+}
+
+func (s *SlackReporter) Name() string {
+	return "slack"
+}
+
+func (s *SlackReporter) Report(event *Event) {
+	slackClient := ry_slack.CurrentSlackClient()
+	ignoreErrorOption := slackClient.GetOption("ignore_error")
+	ignoreError, ok := ignoreErrorOption.(bool)
+	if ok && ignoreError {
+		return
+	}
+
+	slackMsg := fmt.Sprintf("*%s*\n", event.Err.Error())
+	if event.RequestID != "" {
+		slackMsg += fmt.Sprintf("*RequestID:* %s\n", event.RequestID)
+	}
+	if summary := event.Summary(); summary != "" {
+		slackMsg += fmt.Sprintf("*Occurrences:* %s\n", summary)
+	}
+	if event.EventID != "" {
+		slackMsg += fmt.Sprintf("*EventID:* %s\n", event.EventID)
+		if sentryProjectUrl := slackClient.GetOption("sentry_project_url"); sentryProjectUrl != nil {
+			slackMsg += fmt.Sprintf("<%s?query=%s|See more detail>", sentryProjectUrl, event.EventID)
+		}
+	}
+	if errChannel := slackClient.GetOption("error_channel"); errChannel != nil {
+		_ = ry_slack.SendGroupedMessageToChannel(errChannel.(string), event.Fingerprint, "ry-api error", slackMsg)
+	} else {
+		_ = ry_slack.SendGroupedMessage(event.Fingerprint, "ry-api error", slackMsg)
+	}
+}
+
+// RecordReporter keeps reported events in memory. Useful to assert what got reported in tests
+type RecordReporter struct {
+	lock   sync.Mutex
+	events []Event
+	extras map[string]interface{}
+}
+
+func NewRecordReporter() *RecordReporter {
+	return &RecordReporter{extras: map[string]interface{}{}}
+}
+
+func (r *RecordReporter) Name() string {
+	return "recorder"
+}
+
+func (r *RecordReporter) Report(event *Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, *event)
+}
+
+func (r *RecordReporter) SetExtra(key string, value interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.extras[key] = value
+}
+
+// Events returns the recorded events
+func (r *RecordReporter) Events() []Event {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	res := make([]Event, len(r.events))
+	copy(res, r.events)
+	return res
+}
+
+// Extras returns the recorded extras
+func (r *RecordReporter) Extras() map[string]interface{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	res := make(map[string]interface{}, len(r.extras))
+	for k, v := range r.extras {
+		res[k] = v
+	}
+	return res
+}
+
+// Reset clears the recorded events and extras
+func (r *RecordReporter) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = nil
+	r.extras = map[string]interface{}{}
+}
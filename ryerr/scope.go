@@ -0,0 +1,176 @@
+package ryerr
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ScopeGinKey is the gin context key of the request Scope, so a *gin.Context can be used as context
+const ScopeGinKey = "ryerr_scope"
+
+type scopeCtxKey struct{}
+
+// User is the user attached to the reports of a Scope
+type User struct {
+	ID        string
+	Email     string
+	Username  string
+	IPAddress string
+}
+
+// Breadcrumb is a trail of events that happened before an error was reported
+type Breadcrumb struct {
+	Category  string
+	Message   string
+	Level     string
+	Data      map[string]interface{}
+	Timestamp time.Time
+}
+
+// Scope holds the report data of a single request: extras, tags, user and breadcrumbs
+type Scope struct {
+	lock        sync.RWMutex
+	extras      map[string]interface{}
+	tags        map[string]string
+	user        *User
+	breadcrumbs []Breadcrumb
+}
+
+// maxBreadcrumbs is the number of breadcrumbs kept per scope
+const maxBreadcrumbs = 50
+
+func NewScope() *Scope {
+	return &Scope{
+		extras: map[string]interface{}{},
+		tags:   map[string]string{},
+	}
+}
+
+func (s *Scope) SetExtra(key string, value interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.extras[key] = value
+}
+
+func (s *Scope) SetTag(key string, value string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tags[key] = value
+}
+
+func (s *Scope) SetUser(user User) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.user = &user
+}
+
+func (s *Scope) AddBreadcrumb(breadcrumb Breadcrumb) {
+	if breadcrumb.Timestamp.IsZero() {
+		breadcrumb.Timestamp = time.Now()
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.breadcrumbs = append(s.breadcrumbs, breadcrumb)
+	if len(s.breadcrumbs) > maxBreadcrumbs {
+		s.breadcrumbs = s.breadcrumbs[len(s.breadcrumbs)-maxBreadcrumbs:]
+	}
+}
+
+// Extras returns a copy of the scope extras
+func (s *Scope) Extras() map[string]interface{} {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	res := make(map[string]interface{}, len(s.extras))
+	for k, v := range s.extras {
+		res[k] = v
+	}
+	return res
+}
+
+// Tags returns a copy of the scope tags
+func (s *Scope) Tags() map[string]string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	res := make(map[string]string, len(s.tags))
+	for k, v := range s.tags {
+		res[k] = v
+	}
+	return res
+}
+
+// User returns the scope user, nil if not set
+func (s *Scope) User() *User {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.user == nil {
+		return nil
+	}
+	user := *s.user
+	return &user
+}
+
+// Breadcrumbs returns a copy of the scope breadcrumbs
+func (s *Scope) Breadcrumbs() []Breadcrumb {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	res := make([]Breadcrumb, len(s.breadcrumbs))
+	copy(res, s.breadcrumbs)
+	return res
+}
+
+// WithScope returns a context holding a new Scope. The context is returned as is if it already has one
+func WithScope(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ScopeFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, scopeCtxKey{}, NewScope())
+}
+
+// ScopeFromContext returns the Scope held in the context, nil if there is none
+func ScopeFromContext(ctx context.Context) *Scope {
+	if ctx == nil {
+		return nil
+	}
+	if scope, ok := ctx.Value(scopeCtxKey{}).(*Scope); ok {
+		return scope
+	}
+	if scope, ok := ctx.Value(ScopeGinKey).(*Scope); ok {
+		return scope
+	}
+	return nil
+}
+
+// SetExtraCtx attaches extra data to the reports of the context scope.
+// Falls back to SetExtra when the context has no scope
+func SetExtraCtx(ctx context.Context, key string, value interface{}) {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		scope.SetExtra(key, value)
+		return
+	}
+	SetExtra(key, value)
+}
+
+// SetTagCtx attaches a tag to the reports of the context scope
+func SetTagCtx(ctx context.Context, key string, value string) {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		scope.SetTag(key, value)
+	}
+}
+
+// SetUserCtx attaches a user to the reports of the context scope
+func SetUserCtx(ctx context.Context, user User) {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		scope.SetUser(user)
+	}
+}
+
+// AddBreadcrumbCtx adds a breadcrumb to the context scope
+func AddBreadcrumbCtx(ctx context.Context, breadcrumb Breadcrumb) {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		scope.AddBreadcrumb(breadcrumb)
+	}
+}
@@ -0,0 +1,137 @@
+package ryerr
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const defaultStackDepth = 32
+
+var (
+	stackRWLock  sync.RWMutex
+	stackDepth   = defaultStackDepth
+	stackFilters = defaultStackFilters()
+)
+
+func defaultStackFilters() []string {
+	return []string{
+		"runtime.",
+		"github.com/gin-gonic/gin",
+		reflect.TypeOf(Err{}).PkgPath() + ".", // frames inside ryerr itself
+	}
+}
+
+// SetStackDepth sets the maximum number of frames captured when an Err is created
+func SetStackDepth(depth int) {
+	stackRWLock.Lock()
+	defer stackRWLock.Unlock()
+	stackDepth = depth
+}
+
+// AddStackFilter skips the frames whose function name starts with one of the prefixes
+func AddStackFilter(prefixes ...string) {
+	stackRWLock.Lock()
+	defer stackRWLock.Unlock()
+	stackFilters = append(stackFilters[:len(stackFilters):len(stackFilters)], prefixes...)
+}
+
+// ResetStackFilters restores the default frame filters
+func ResetStackFilters() {
+	stackRWLock.Lock()
+	defer stackRWLock.Unlock()
+	stackFilters = defaultStackFilters()
+}
+
+func isFilteredFrame(fnName string, filters []string) bool {
+	for _, prefix := range filters {
+		if strings.HasPrefix(fnName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// callers captures the program counters of the current goroutine, without the filtered frames
+func callers(skip int) []uintptr {
+	stackRWLock.RLock()
+	depth := stackDepth
+	filters := stackFilters
+	stackRWLock.RUnlock()
+	if depth <= 0 {
+		return nil
+	}
+
+	pcs := make([]uintptr, depth+len(filters)+16)
+	n := runtime.Callers(skip, pcs)
+	res := make([]uintptr, 0, depth)
+	for _, pc := range pcs[:n] {
+		if len(res) == depth {
+			break
+		}
+		// pc is a return address, pc-1 is inside the calling instruction
+		if f := runtime.FuncForPC(pc - 1); f != nil && isFilteredFrame(f.Name(), filters) {
+			continue
+		}
+		res = append(res, pc)
+	}
+
+	return res
+}
+
+// callersOf keeps the stack of the first Err in the chain, so the stack points where the error was created.
+// A new stack is captured when there is none
+func callersOf(err error) []uintptr {
+	if customErr, ok := asErr(err); ok && len(customErr.callers) > 0 {
+		return customErr.callers
+	}
+	return callers(3)
+}
+
+// StackTrace returns the stack captured when the error was created or first wrapped.
+// It follows the github.com/pkg/errors interface, so it works with %+v and Sentry stacktrace extraction
+func (c Err) StackTrace() errors.StackTrace {
+	frames := make(errors.StackTrace, len(c.callers))
+	for i, pc := range c.callers {
+		frames[i] = errors.Frame(pc)
+	}
+	return frames
+}
+
+// Format prints the error message. %+v also prints the stack trace
+func (c Err) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, c.Error())
+			c.StackTrace().Format(s, verb)
+			return
+		}
+		_, _ = io.WriteString(s, c.Error())
+	case 's':
+		_, _ = io.WriteString(s, c.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", c.Error())
+	}
+}
+
+// stackTraceLines formats the captured frames as file:line@function
+func (c Err) stackTraceLines() []string {
+	var res []string
+	frames := runtime.CallersFrames(c.callers)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			res = append(res, fmt.Sprintf("%s:%d@%s", frame.File, frame.Line, frame.Function))
+		}
+		if !more {
+			break
+		}
+	}
+	return res
+}
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"path"
@@ -30,6 +31,11 @@ type Configuration struct {
 
 // Store Store the file
 func (s *S3) Store(file io.Reader, filename string, filePath string, opts ...stgoption.OptionFunc) (location *string, err error) {
+	return s.StoreCtx(context.Background(), file, filename, filePath, opts...)
+}
+
+// StoreCtx Store the file, the upload is cancelled with the context
+func (s *S3) StoreCtx(ctx context.Context, file io.Reader, filename string, filePath string, opts ...stgoption.OptionFunc) (location *string, err error) {
 	options := stgoption.GetDefaultOptions()
 	for _, o := range opts {
 		o(&options)
@@ -46,7 +52,7 @@ func (s *S3) Store(file io.Reader, filename string, filePath string, opts ...stg
 		ContentType:        aws.String(mimeType),
 	}
 
-	result, err := s.uploader.Upload(upParams)
+	result, err := s.uploader.UploadWithContext(ctx, upParams)
 	if err != nil {
 		errMsg := fmt.Sprintf("Error: Cannot store file to S3. Error: %v", err)
 		return nil, ryerr.BadRequest.New(errMsg)
@@ -57,12 +63,17 @@ func (s *S3) Store(file io.Reader, filename string, filePath string, opts ...stg
 
 // Delete Delete the file
 func (s *S3) Delete(fullPath string) error {
-	_, err := s.service.DeleteObject(&s3.DeleteObjectInput{Bucket: aws.String(s.config.Bucket), Key: aws.String(fullPath)})
+	return s.DeleteCtx(context.Background(), fullPath)
+}
+
+// DeleteCtx Delete the file, the deletion is cancelled with the context
+func (s *S3) DeleteCtx(ctx context.Context, fullPath string) error {
+	_, err := s.service.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{Bucket: aws.String(s.config.Bucket), Key: aws.String(fullPath)})
 	if err != nil {
 		return err
 	}
 
-	err = s.service.WaitUntilObjectNotExists(&s3.HeadObjectInput{
+	err = s.service.WaitUntilObjectNotExistsWithContext(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(s.config.Bucket),
 		Key:    aws.String(fullPath),
 	})
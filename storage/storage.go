@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"context"
 	"io"
 
+	"github.com/rayyone/go-core/ryerr"
 	stgoption "github.com/rayyone/go-core/storage/option"
 	"github.com/rayyone/go-core/storage/s3"
 )
@@ -19,6 +21,13 @@ type Driver interface {
 	Delete(fullPath string) error
 }
 
+// ContextDriver is a Driver whose operations are cancelled with the context, e.g. corecontainer.Request.Ctx
+type ContextDriver interface {
+	Driver
+	StoreCtx(ctx context.Context, file io.Reader, filename string, filePath string, opts ...stgoption.OptionFunc) (location *string, err error)
+	DeleteCtx(ctx context.Context, fullPath string) error
+}
+
 // Storage Storage
 type Storage struct {
 	driver Driver
@@ -29,11 +38,33 @@ func (s *Storage) Store(file io.Reader, filename string, filePath string, opts .
 	return s.driver.Store(file, filename, filePath, opts...)
 }
 
+// StoreCtx Store file based on driver, unless the context is done. A ContextDriver cancels the storing with the context
+func (s *Storage) StoreCtx(ctx context.Context, file io.Reader, filename string, filePath string, opts ...stgoption.OptionFunc) (location *string, err error) {
+	if driver, ok := s.driver.(ContextDriver); ok {
+		return driver.StoreCtx(ctx, file, filename, filePath, opts...)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, ryerr.Wrap(err, "File not stored")
+	}
+	return s.driver.Store(file, filename, filePath, opts...)
+}
+
 // Delete Delete file based on driver
 func (s *Storage) Delete(fullPath string) error {
 	return s.driver.Delete(fullPath)
 }
 
+// DeleteCtx Delete file based on driver, unless the context is done. A ContextDriver cancels the deletion with the context
+func (s *Storage) DeleteCtx(ctx context.Context, fullPath string) error {
+	if driver, ok := s.driver.(ContextDriver); ok {
+		return driver.DeleteCtx(ctx, fullPath)
+	}
+	if err := ctx.Err(); err != nil {
+		return ryerr.Wrap(err, "File not deleted")
+	}
+	return s.driver.Delete(fullPath)
+}
+
 // Driver Set driver
 func (s *Storage) Driver(driver Driver) *Storage {
 	return &Storage{driver: driver}